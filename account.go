@@ -0,0 +1,40 @@
+package rdstation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/internal/client"
+)
+
+// AccountInfo returns the account the credentials belong to. The errors
+// returned by this client carry the account id, which is looked up on the
+// first failure when AccountInfo was not called before.
+func (rd rdStation) AccountInfo() (*entity.AccountInfo, error) {
+	var info entity.AccountInfo
+	err := rd.get(fmt.Sprintf("%s%s", RDURL, RDAccountInfoPath), &info)
+	if err != nil {
+		return nil, err
+	}
+
+	if rd.account != nil && info.ID != "" {
+		rd.account.SetAccountID(info.ID)
+	}
+
+	return &info, nil
+}
+
+// lookupAccountID fetches the account id for client.WithAccountLookup.
+func lookupAccountID(c client.Client) (string, error) {
+	data, err := c.Request(fmt.Sprintf("%s%s", RDURL, RDAccountInfoPath), http.MethodGet, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var info entity.AccountInfo
+	err = json.Unmarshal(data, &info)
+
+	return info.ID, err
+}
@@ -0,0 +1,75 @@
+package rdstation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/internal/client"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_AccountInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mock := mocks.NewMockClient(ctrl)
+
+	account := client.WithAccount(mock)
+	rd := &rdStation{client: account, account: account}
+	require.NotNil(t, rd)
+
+	url := fmt.Sprintf("%s%s", RDURL, RDAccountInfoPath)
+	notFound := RDError{Errors: Errors{StatusCode: http.StatusNotFound}}
+
+	mock.EXPECT().Request(fmt.Sprintf("%s%semail:%s", RDURL, RDLeadPath, "email"), http.MethodGet, nil).
+		Return(nil, notFound).Times(2)
+
+	_, err := rd.GetLeadByEmail("email")
+	require.Equal(t, notFound, err)
+
+	mock.EXPECT().Request(url, http.MethodGet, nil).Return([]byte(`{"id": 3127612, "name": "Qual"}`), nil)
+
+	info, err := rd.AccountInfo()
+	require.NoError(t, err)
+	require.Equal(t, "3127612", info.ID)
+	require.Equal(t, "Qual", info.Name)
+
+	_, err = rd.GetLeadByEmail("email")
+	var rdErr RDError
+	require.True(t, errors.As(err, &rdErr))
+	require.Equal(t, "3127612", rdErr.Errors.AccountID)
+
+	t.Run("error", func(t *testing.T) {
+		mock.EXPECT().Request(url, http.MethodGet, nil).Return(nil, errors.New("batata"))
+
+		info, err := rd.AccountInfo()
+		require.Error(t, err)
+		require.Nil(t, info)
+	})
+}
+
+func TestRdStation_AccountLookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mock := mocks.NewMockClient(ctrl)
+
+	account := client.WithAccountLookup(mock, lookupAccountID)
+	rd := &rdStation{client: account, account: account}
+
+	notFound := RDError{Errors: Errors{StatusCode: http.StatusNotFound}}
+	mock.EXPECT().Request(fmt.Sprintf("%s%semail:%s", RDURL, RDLeadPath, "email"), http.MethodGet, nil).
+		Return(nil, notFound).Times(2)
+	mock.EXPECT().Request(fmt.Sprintf("%s%s", RDURL, RDAccountInfoPath), http.MethodGet, nil).
+		Return([]byte(`{"id": 3127612, "name": "Qual"}`), nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		_, err := rd.GetLeadByEmail("email")
+		var rdErr RDError
+		require.True(t, errors.As(err, &rdErr))
+		require.Equal(t, "3127612", rdErr.Errors.AccountID)
+	}
+}
@@ -0,0 +1,113 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE
+package rdstation
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type AnalyticsService interface {
+	EmailAnalytics(dates entity.DateRange) (*entity.EmailAnalytics, error)
+	ConversionAnalytics(dates entity.DateRange, assetTypes ...string) (*entity.ConversionAnalytics, error)
+	DailyConversionAnalytics(dates entity.DateRange, assetTypes ...string) ([]entity.ConversionPoint, error)
+	FunnelAnalytics(dates entity.DateRange) (*entity.FunnelAnalytics, error)
+}
+
+func (rd rdStation) EmailAnalytics(dates entity.DateRange) (*entity.EmailAnalytics, error) {
+	var analytics entity.EmailAnalytics
+	err := rd.get(analyticsURL(RDEmailAnalyticsPath, dates, nil), &analytics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &analytics, nil
+}
+
+// ConversionAnalytics returns the visits and conversions of each asset in the
+// period, optionally restricted to some asset types (entity.AssetLandingPage...).
+func (rd rdStation) ConversionAnalytics(dates entity.DateRange, assetTypes ...string) (*entity.ConversionAnalytics, error) {
+	query := url.Values{}
+	for _, assetType := range assetTypes {
+		query.Add("assets_type[]", assetType)
+	}
+
+	var analytics entity.ConversionAnalytics
+	err := rd.get(analyticsURL(RDConversionAnalyticsPath, dates, query), &analytics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &analytics, nil
+}
+
+// DailyConversionAnalytics splits the period in days, as the api only
+// aggregates conversions over the whole range, and queries each one.
+func (rd rdStation) DailyConversionAnalytics(dates entity.DateRange, assetTypes ...string) ([]entity.ConversionPoint, error) {
+	start, err := time.Parse(entity.AnalyticsDateLayout, dates.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := time.Parse(entity.AnalyticsDateLayout, dates.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var series []entity.ConversionPoint
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		analytics, err := rd.ConversionAnalytics(entity.NewDateRange(day, day), assetTypes...)
+		if err != nil {
+			return nil, err
+		}
+
+		series = append(series, entity.ConversionPoint{
+			Date:   day.Format(entity.AnalyticsDateLayout),
+			Assets: analytics.Assets,
+		})
+	}
+
+	return series, nil
+}
+
+// FunnelAnalytics returns the funnel of each day in the period, sorted by
+// date.
+func (rd rdStation) FunnelAnalytics(dates entity.DateRange) (*entity.FunnelAnalytics, error) {
+	var response struct {
+		AccountID int                           `json:"account_id"`
+		QueryDate entity.DateRange              `json:"query_date"`
+		Funnel    map[string]entity.FunnelPoint `json:"funnel"`
+	}
+	err := rd.get(analyticsURL(RDFunnelAnalyticsPath, dates, nil), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := entity.FunnelAnalytics{
+		AccountID: response.AccountID,
+		QueryDate: response.QueryDate,
+		Series:    make([]entity.FunnelPoint, 0, len(response.Funnel)),
+	}
+	for date, point := range response.Funnel {
+		point.Date = date
+		analytics.Series = append(analytics.Series, point)
+	}
+	sort.Slice(analytics.Series, func(i, j int) bool {
+		return analytics.Series[i].Date < analytics.Series[j].Date
+	})
+
+	return &analytics, nil
+}
+
+func analyticsURL(path string, dates entity.DateRange, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("start_date", dates.StartDate)
+	query.Set("end_date", dates.EndDate)
+
+	return fmt.Sprintf("%s%s?%s", RDURL, path, query.Encode())
+}
@@ -0,0 +1,150 @@
+package rdstation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_EmailAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	dates := entity.NewDateRange(time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 7, 7, 0, 0, 0, 0, time.UTC))
+	url := fmt.Sprintf("%s%s?end_date=2022-07-07&start_date=2022-07-01", RDURL, RDEmailAnalyticsPath)
+
+	t.Run("success", func(t *testing.T) {
+		response := []byte(`{
+			"account_id": 3127612,
+			"query_date": {"start_date": "2022-07-01", "end_date": "2022-07-07"},
+			"emails": [{
+				"campaign_id": 6061281,
+				"campaign_name": "Newsletter",
+				"send_at": "2022-07-04T10:00:00-03:00",
+				"contacts_count": 4,
+				"email_dropped_count": 0,
+				"email_delivered_count": 4,
+				"email_bounced_count": 0,
+				"email_opened_count": 2,
+				"email_clicked_count": 1,
+				"email_unsubscribed_count": 0,
+				"email_spam_reported_count": 0,
+				"email_delivered_rate": 100.0,
+				"email_opened_rate": 50.0,
+				"email_clicked_rate": 25.0,
+				"email_spam_reported_rate": 0.0
+			}]
+		}`)
+
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(response, nil)
+
+		got, err := rd.EmailAnalytics(dates)
+		require.NoError(t, err)
+		require.Equal(t, 3127612, got.AccountID)
+		require.Equal(t, dates, got.QueryDate)
+		require.Len(t, got.Emails, 1)
+		require.Equal(t, "Newsletter", got.Emails[0].CampaignName)
+		require.Equal(t, 2, got.Emails[0].OpenedCount)
+		require.Equal(t, 25.0, got.Emails[0].ClickedRate)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(nil, errors.New("batata"))
+
+		got, err := rd.EmailAnalytics(dates)
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+}
+
+func TestRdStation_ConversionAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	response := []byte(`{
+		"account_id": 3127612,
+		"query_date": {"start_date": "2022-07-01", "end_date": "2022-07-01"},
+		"assets": [{
+			"asset_id": 1,
+			"asset_identifier": "lp-demo",
+			"asset_type": "LandingPage",
+			"visits_count": 10,
+			"conversions_count": 2,
+			"conversion_rate": 20.0
+		}]
+	}`)
+
+	t.Run("filtered", func(t *testing.T) {
+		dates := entity.DateRange{StartDate: "2022-07-01", EndDate: "2022-07-01"}
+		url := fmt.Sprintf("%s%s?assets_type%%5B%%5D=LandingPage&end_date=2022-07-01&start_date=2022-07-01", RDURL, RDConversionAnalyticsPath)
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(response, nil)
+
+		got, err := rd.ConversionAnalytics(dates, entity.AssetLandingPage)
+		require.NoError(t, err)
+		require.Len(t, got.Assets, 1)
+		require.Equal(t, "lp-demo", got.Assets[0].AssetIdentifier)
+		require.Equal(t, 20.0, got.Assets[0].ConversionRate)
+	})
+
+	t.Run("daily", func(t *testing.T) {
+		for _, day := range []string{"2022-07-01", "2022-07-02"} {
+			url := fmt.Sprintf("%s%s?end_date=%s&start_date=%s", RDURL, RDConversionAnalyticsPath, day, day)
+			client.EXPECT().Request(url, http.MethodGet, nil).Return(response, nil)
+		}
+
+		got, err := rd.DailyConversionAnalytics(entity.DateRange{StartDate: "2022-07-01", EndDate: "2022-07-02"})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, "2022-07-02", got[1].Date)
+		require.Equal(t, 2, got[1].Assets[0].ConversionsCount)
+	})
+
+	t.Run("daily invalid date", func(t *testing.T) {
+		_, err := rd.DailyConversionAnalytics(entity.DateRange{StartDate: "ontem", EndDate: "2022-07-02"})
+		require.Error(t, err)
+	})
+}
+
+func TestRdStation_FunnelAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	dates := entity.DateRange{StartDate: "2022-07-01", EndDate: "2022-07-02"}
+	url := fmt.Sprintf("%s%s?end_date=2022-07-02&start_date=2022-07-01", RDURL, RDFunnelAnalyticsPath)
+
+	client.EXPECT().Request(url, http.MethodGet, nil).Return([]byte(`{
+		"account_id": 3127612,
+		"query_date": {"start_date": "2022-07-01", "end_date": "2022-07-02"},
+		"funnel": {
+			"2022-07-02": {"visitors_count": 20, "contacts_count": 4, "qualified_contacts_count": 2, "opportunities_count": 1, "sales_count": 0},
+			"2022-07-01": {"visitors_count": 10, "contacts_count": 2, "qualified_contacts_count": 1, "opportunities_count": 1, "sales_count": 1}
+		}
+	}`), nil)
+
+	got, err := rd.FunnelAnalytics(dates)
+	require.NoError(t, err)
+	require.Equal(t, []entity.FunnelPoint{
+		{Date: "2022-07-01", VisitorsCount: 10, ContactsCount: 2, QualifiedContactsCount: 1, OpportunitiesCount: 1, SalesCount: 1},
+		{Date: "2022-07-02", VisitorsCount: 20, ContactsCount: 4, QualifiedContactsCount: 2, OpportunitiesCount: 1},
+	}, got.Series)
+}
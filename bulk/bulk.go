@@ -0,0 +1,240 @@
+// Package bulk runs large numbers of contact operations against RD Station
+// using a pool of workers.
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+type Kind string
+
+const (
+	Create Kind = "create"
+	Update Kind = "update"
+	Upsert Kind = "upsert"
+	Tag    Kind = "tag"
+	Untag  Kind = "untag"
+	Delete Kind = "delete"
+)
+
+// Operation is a single change to a contact. Lead must carry at least the
+// email, Tags is only used by Tag and Untag.
+type Operation struct {
+	Kind Kind
+	Lead *entity.Lead
+	Tags []string
+}
+
+// Result is the outcome of an operation. Index is the position of the
+// operation in the input stream.
+type Result struct {
+	Index     int
+	Operation Operation
+	Err       error
+}
+
+// Report summarizes a run. Failed holds permanent errors, Retriable holds
+// errors such as 429 and 5xx that are likely to succeed if sent again.
+// Skipped counts operations already applied according to the checkpoint.
+type Report struct {
+	Succeeded int
+	Skipped   int
+	Failed    []Result
+	Retriable []Result
+}
+
+type Executor struct {
+	rd          rdstation.RDStation
+	concurrency int
+	checkpoint  CheckpointStore
+	contacts    contactLocks
+}
+
+// NewExecutor returns an Executor that runs up to concurrency operations at
+// a time. Requests still go through the rate limit configured on rd.
+// Operations on the same email run one at a time, so Tag and Untag, which
+// read the tags before replacing them, do not overwrite each other.
+func NewExecutor(rd rdstation.RDStation, concurrency int) *Executor {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	return &Executor{
+		rd:          rd,
+		concurrency: concurrency,
+	}
+}
+
+// WithCheckpoint makes the executor skip operations whose Key is already in
+// store and record each successful one, so an interrupted job can be rerun
+// with the same operations in the same order.
+func (e *Executor) WithCheckpoint(store CheckpointStore) *Executor {
+	e.checkpoint = store
+	return e
+}
+
+// Run consumes ops until the channel is closed or ctx is done. Operations not
+// read from the channel before cancellation are left out of the report.
+func (e *Executor) Run(ctx context.Context, ops <-chan Operation) (Report, error) {
+	var (
+		report  Report
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		indexed = make(chan Result)
+	)
+
+	for i := 0; i < e.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for result := range indexed {
+				var skipped bool
+				skipped, result.Err = e.run(result.Index, result.Operation)
+
+				mu.Lock()
+				switch {
+				case skipped:
+					report.Skipped++
+				case result.Err == nil:
+					report.Succeeded++
+				case rdstation.IsRetriable(result.Err):
+					report.Retriable = append(report.Retriable, result)
+				default:
+					report.Failed = append(report.Failed, result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	err := feed(ctx, ops, indexed)
+	close(indexed)
+	wg.Wait()
+
+	return report, err
+}
+
+func feed(ctx context.Context, ops <-chan Operation, indexed chan<- Result) error {
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case op, ok := <-ops:
+			if !ok {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case indexed <- Result{Index: i, Operation: op}:
+			}
+		}
+	}
+}
+
+func (e *Executor) run(index int, op Operation) (bool, error) {
+	if e.checkpoint == nil {
+		return false, e.apply(op)
+	}
+
+	key := Key(index, op)
+	done, err := e.checkpoint.Done(key)
+	if err != nil {
+		return false, err
+	}
+	if done {
+		return true, nil
+	}
+
+	err = e.apply(op)
+	if err != nil {
+		return false, err
+	}
+
+	return false, e.checkpoint.MarkDone(key)
+}
+
+func (e *Executor) apply(op Operation) error {
+	if op.Lead == nil || op.Lead.Email == "" {
+		return fmt.Errorf("bulk: %s operation without lead email", op.Kind)
+	}
+
+	unlock := e.contacts.lock(op.Lead.Email)
+	defer unlock()
+
+	switch op.Kind {
+	case Create:
+		_, err := e.rd.CreateLead(op.Lead)
+		return err
+	case Update:
+		return e.rd.UpdateLead(op.Lead)
+	case Upsert:
+		_, err := e.rd.UpsertLead(op.Lead)
+		return err
+	case Tag, Untag:
+		// tags are replaced as a whole, so the current ones are needed
+		lead, err := e.rd.GetLeadByEmail(op.Lead.Email)
+		if err != nil {
+			return err
+		}
+
+		if op.Kind == Tag {
+			return e.rd.AddTags(lead, op.Tags)
+		}
+
+		return e.rd.RemoveTags(lead, op.Tags)
+	case Delete:
+		return e.rd.DeleteLeadByEmail(op.Lead.Email)
+	}
+
+	return fmt.Errorf("bulk: unknown operation %q", op.Kind)
+}
+
+// contactLocks holds a mutex per email while operations on it are running.
+type contactLocks struct {
+	mu    sync.Mutex
+	locks map[string]*contactLock
+}
+
+type contactLock struct {
+	sync.Mutex
+	waiting int
+}
+
+func (c *contactLocks) lock(email string) func() {
+	email = strings.ToLower(email)
+
+	c.mu.Lock()
+	if c.locks == nil {
+		c.locks = map[string]*contactLock{}
+	}
+	l, ok := c.locks[email]
+	if !ok {
+		l = &contactLock{}
+		c.locks[email] = l
+	}
+	l.waiting++
+	c.mu.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		l.waiting--
+		if l.waiting == 0 {
+			delete(c.locks, email)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+type fakeRD struct {
+	rdstation.RDStation
+
+	mu    sync.Mutex
+	leads map[string]*entity.Lead
+	fail  map[string]error
+}
+
+func newFakeRD() *fakeRD {
+	return &fakeRD{
+		leads: map[string]*entity.Lead{},
+		fail:  map[string]error{},
+	}
+}
+
+func (f *fakeRD) store(lead *entity.Lead) (*entity.Lead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail[lead.Email]; err != nil {
+		return nil, err
+	}
+
+	stored := *lead
+	f.leads[lead.Email] = &stored
+
+	return &stored, nil
+}
+
+func (f *fakeRD) CreateLead(lead *entity.Lead) (*entity.Lead, error) { return f.store(lead) }
+func (f *fakeRD) UpsertLead(lead *entity.Lead) (*entity.Lead, error) { return f.store(lead) }
+
+func (f *fakeRD) UpdateLead(lead *entity.Lead) error {
+	_, err := f.store(lead)
+	return err
+}
+
+func (f *fakeRD) GetLeadByEmail(email string) (*entity.Lead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lead, ok := f.leads[email]
+	if !ok {
+		return nil, rdstation.RDError{Errors: rdstation.Errors{StatusCode: http.StatusNotFound}}
+	}
+	copied := *lead
+
+	return &copied, nil
+}
+
+func (f *fakeRD) AddTags(lead *entity.Lead, tags []string) error {
+	lead.Tags = append(lead.Tags, tags...)
+	_, err := f.store(lead)
+	return err
+}
+
+func (f *fakeRD) RemoveTags(lead *entity.Lead, tags []string) error {
+	kept := []string{}
+	for _, tag := range lead.Tags {
+		remove := false
+		for _, t := range tags {
+			remove = remove || t == tag
+		}
+		if !remove {
+			kept = append(kept, tag)
+		}
+	}
+	lead.Tags = kept
+	_, err := f.store(lead)
+	return err
+}
+
+func (f *fakeRD) DeleteLeadByEmail(email string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.leads, email)
+	return nil
+}
+
+func send(ops ...Operation) <-chan Operation {
+	ch := make(chan Operation, len(ops))
+	for _, op := range ops {
+		ch <- op
+	}
+	close(ch)
+
+	return ch
+}
+
+func TestExecutor_Run(t *testing.T) {
+	rd := newFakeRD()
+	rd.leads["tagged@email.com"] = &entity.Lead{Email: "tagged@email.com", Tags: []string{"doce", "solidao"}}
+	rd.leads["delete@email.com"] = &entity.Lead{Email: "delete@email.com"}
+	rd.fail["limited@email.com"] = rdstation.RDError{Errors: rdstation.Errors{StatusCode: http.StatusTooManyRequests}}
+	rd.fail["invalid@email.com"] = errors.New("batata")
+
+	report, err := NewExecutor(rd, 4).Run(context.Background(), send(
+		Operation{Kind: Create, Lead: &entity.Lead{Email: "new@email.com"}},
+		Operation{Kind: Upsert, Lead: &entity.Lead{Email: "upsert@email.com", Name: "nome"}},
+		Operation{Kind: Tag, Lead: &entity.Lead{Email: "tagged@email.com"}, Tags: []string{"salgado"}},
+		Operation{Kind: Untag, Lead: &entity.Lead{Email: "tagged@email.com"}, Tags: []string{"doce"}},
+		Operation{Kind: Delete, Lead: &entity.Lead{Email: "delete@email.com"}},
+		Operation{Kind: Update, Lead: &entity.Lead{Email: "limited@email.com"}},
+		Operation{Kind: Update, Lead: &entity.Lead{Email: "invalid@email.com"}},
+		Operation{Kind: Tag, Lead: &entity.Lead{Email: "missing@email.com"}},
+		Operation{Kind: Create},
+	))
+	require.NoError(t, err)
+
+	require.Equal(t, 5, report.Succeeded)
+	require.Len(t, report.Retriable, 1)
+	require.Equal(t, 5, report.Retriable[0].Index)
+	require.Len(t, report.Failed, 3)
+
+	require.Contains(t, rd.leads, "new@email.com")
+	require.Equal(t, "nome", rd.leads["upsert@email.com"].Name)
+	require.NotContains(t, rd.leads, "delete@email.com")
+}
+
+// slowRD widens the window between reading and writing the tags.
+type slowRD struct {
+	*fakeRD
+}
+
+func (s slowRD) GetLeadByEmail(email string) (*entity.Lead, error) {
+	lead, err := s.fakeRD.GetLeadByEmail(email)
+	time.Sleep(time.Millisecond)
+
+	return lead, err
+}
+
+func TestExecutor_RunSameContact(t *testing.T) {
+	rd := newFakeRD()
+	rd.leads["tagged@email.com"] = &entity.Lead{Email: "tagged@email.com"}
+
+	ops := make([]Operation, 50)
+	for i := range ops {
+		ops[i] = Operation{Kind: Tag, Lead: &entity.Lead{Email: "tagged@email.com"}, Tags: []string{fmt.Sprint("tag-", i)}}
+	}
+
+	report, err := NewExecutor(slowRD{rd}, 8).Run(context.Background(), send(ops...))
+	require.NoError(t, err)
+	require.Equal(t, len(ops), report.Succeeded)
+	require.Len(t, rd.leads["tagged@email.com"].Tags, len(ops))
+}
+
+func TestExecutor_RunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := NewExecutor(newFakeRD(), 2).Run(ctx, make(chan Operation))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Zero(t, report.Succeeded)
+}
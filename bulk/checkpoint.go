@@ -0,0 +1,114 @@
+package bulk
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// CheckpointStore remembers which operations were already applied so a
+// rerun of the same job can skip them.
+type CheckpointStore interface {
+	Done(key string) (bool, error)
+	MarkDone(key string) error
+}
+
+// Key derives the idempotency key of an operation from its position in the
+// input stream, its kind and payload. Rerunning the same input yields the same
+// keys, while repeated operations, such as a Tag undone by an Untag and done
+// again, get a key each.
+func Key(index int, op Operation) string {
+	hash := sha256.New()
+	hash.Write([]byte(strconv.Itoa(index)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(op.Kind))
+	hash.Write([]byte{0})
+	// a marshal error only happens with unsupported custom field values,
+	// which the API would reject anyway
+	lead, _ := json.Marshal(op.Lead)
+	hash.Write(lead)
+	hash.Write([]byte{0})
+	tags, _ := json.Marshal(op.Tags)
+	hash.Write(tags)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type MemoryCheckpoint struct {
+	mu   sync.RWMutex
+	done map[string]struct{}
+}
+
+func NewMemoryCheckpoint() *MemoryCheckpoint {
+	return &MemoryCheckpoint{done: map[string]struct{}{}}
+}
+
+func (m *MemoryCheckpoint) Done(key string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.done[key]
+	return ok, nil
+}
+
+func (m *MemoryCheckpoint) MarkDone(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.done[key] = struct{}{}
+	return nil
+}
+
+// FileCheckpoint keeps completed keys in memory and appends each new one as a
+// line to a file, which is read back when the checkpoint is reopened.
+type FileCheckpoint struct {
+	MemoryCheckpoint
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func OpenFileCheckpoint(path string) (*FileCheckpoint, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &FileCheckpoint{
+		MemoryCheckpoint: MemoryCheckpoint{done: map[string]struct{}{}},
+		file:             file,
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if key := scanner.Text(); key != "" {
+			checkpoint.done[key] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+func (f *FileCheckpoint) MarkDone(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err := f.file.WriteString(key + "\n")
+	if err != nil {
+		return err
+	}
+
+	return f.MemoryCheckpoint.MarkDone(key)
+}
+
+func (f *FileCheckpoint) Close() error {
+	return f.file.Close()
+}
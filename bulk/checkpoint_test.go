@@ -0,0 +1,92 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+func TestKey(t *testing.T) {
+	op := Operation{Kind: Tag, Lead: &entity.Lead{Email: "a@email.com"}, Tags: []string{"doce"}}
+	same := Operation{Kind: Tag, Lead: &entity.Lead{Email: "a@email.com"}, Tags: []string{"doce"}}
+
+	require.Equal(t, Key(0, op), Key(0, same))
+	require.NotEqual(t, Key(0, op), Key(2, same))
+
+	same.Kind = Untag
+	require.NotEqual(t, Key(0, op), Key(0, same))
+
+	same.Kind = Tag
+	same.Tags = []string{"salgado"}
+	require.NotEqual(t, Key(0, op), Key(0, same))
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+
+	checkpoint, err := OpenFileCheckpoint(path)
+	require.NoError(t, err)
+
+	require.NoError(t, checkpoint.MarkDone("a"))
+	require.NoError(t, checkpoint.MarkDone("b"))
+	require.NoError(t, checkpoint.Close())
+
+	checkpoint, err = OpenFileCheckpoint(path)
+	require.NoError(t, err)
+	defer checkpoint.Close()
+
+	done, err := checkpoint.Done("a")
+	require.NoError(t, err)
+	require.True(t, done)
+
+	done, err = checkpoint.Done("c")
+	require.NoError(t, err)
+	require.False(t, done)
+}
+
+func TestExecutor_RunResume(t *testing.T) {
+	rd := newFakeRD()
+	rd.fail["second@email.com"] = errors.New("batata")
+
+	ops := []Operation{
+		{Kind: Upsert, Lead: &entity.Lead{Email: "first@email.com"}},
+		{Kind: Upsert, Lead: &entity.Lead{Email: "second@email.com"}},
+	}
+
+	checkpoint := NewMemoryCheckpoint()
+	executor := NewExecutor(rd, 1).WithCheckpoint(checkpoint)
+
+	report, err := executor.Run(context.Background(), send(ops...))
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Succeeded)
+	require.Len(t, report.Failed, 1)
+
+	delete(rd.fail, "second@email.com")
+	delete(rd.leads, "first@email.com")
+
+	report, err = executor.Run(context.Background(), send(ops...))
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Skipped)
+	require.Equal(t, 1, report.Succeeded)
+	require.NotContains(t, rd.leads, "first@email.com")
+	require.Contains(t, rd.leads, "second@email.com")
+}
+
+func TestExecutor_RunResumeRepeated(t *testing.T) {
+	rd := newFakeRD()
+	rd.leads["tagged@email.com"] = &entity.Lead{Email: "tagged@email.com"}
+
+	tag := Operation{Kind: Tag, Lead: &entity.Lead{Email: "tagged@email.com"}, Tags: []string{"doce"}}
+	untag := Operation{Kind: Untag, Lead: &entity.Lead{Email: "tagged@email.com"}, Tags: []string{"doce"}}
+
+	report, err := NewExecutor(rd, 1).WithCheckpoint(NewMemoryCheckpoint()).Run(context.Background(), send(tag, untag, tag))
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Succeeded)
+	require.Zero(t, report.Skipped)
+	require.Equal(t, []string{"doce"}, rd.leads["tagged@email.com"].Tags)
+}
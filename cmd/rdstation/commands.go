@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/leadcsv"
+)
+
+// leadFlags fills a lead from the command line.
+type leadFlags struct {
+	lead   entity.Lead
+	tags   string
+	fields customFields
+}
+
+func addLeadFlags(flags *flag.FlagSet) *leadFlags {
+	l := &leadFlags{fields: customFields{}}
+	flags.StringVar(&l.lead.Email, "email", "", "lead email")
+	flags.StringVar(&l.lead.Name, "name", "", "lead name")
+	flags.StringVar(&l.lead.JobTitle, "job-title", "", "job title")
+	flags.StringVar(&l.lead.PersonalPhone, "phone", "", "personal phone")
+	flags.StringVar(&l.lead.MobilePhone, "mobile-phone", "", "mobile phone")
+	flags.StringVar(&l.lead.City, "city", "", "city")
+	flags.StringVar(&l.lead.State, "state", "", "state")
+	flags.StringVar(&l.lead.Country, "country", "", "country")
+	flags.StringVar(&l.lead.Website, "website", "", "website")
+	flags.StringVar(&l.tags, "tags", "", "comma separated tags")
+	flags.Var(l.fields, "field", "custom field as cf_name=value, repeatable")
+
+	return l
+}
+
+func (l *leadFlags) parse(flags *flag.FlagSet, args []string) (*entity.Lead, error) {
+	positional, err := parse(flags, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 || l.lead.Email == "" {
+		return nil, errUsage
+	}
+
+	lead := l.lead
+	lead.Tags = splitList(l.tags)
+	if len(l.fields) > 0 {
+		lead.CustomFields = l.fields
+	}
+
+	return &lead, nil
+}
+
+type customFields map[string]interface{}
+
+func (f customFields) String() string {
+	return fmt.Sprint(map[string]interface{}(f))
+}
+
+func (f customFields) Set(value string) error {
+	name, field, ok := strings.Cut(value, "=")
+	if !ok || !strings.HasPrefix(name, entity.CustomFieldPrefix) {
+		return fmt.Errorf("%q is not cf_name=value", value)
+	}
+	f[name] = field
+
+	return nil
+}
+
+func getLead(e *env, connect connector, out printer, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	var lead *entity.Lead
+	if strings.Contains(args[0], "@") {
+		lead, err = rd.GetLeadByEmail(args[0])
+	} else {
+		lead, err = rd.GetLeadByUUID(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	return out.lead(lead)
+}
+
+func createLead(e *env, connect connector, out printer, args []string) error {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	l := addLeadFlags(flags)
+	lead, err := l.parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	lead, err = rd.CreateLead(lead)
+	if err != nil {
+		return err
+	}
+
+	return out.lead(lead)
+}
+
+func updateLead(e *env, connect connector, out printer, args []string) error {
+	flags := flag.NewFlagSet("update", flag.ContinueOnError)
+	l := addLeadFlags(flags)
+	lead, err := l.parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	err = rd.UpdateLead(lead)
+	if err != nil {
+		return err
+	}
+
+	lead, err = rd.GetLeadByEmail(lead.Email)
+	if err != nil {
+		return err
+	}
+
+	return out.lead(lead)
+}
+
+func upsertLead(e *env, connect connector, out printer, args []string) error {
+	flags := flag.NewFlagSet("upsert", flag.ContinueOnError)
+	l := addLeadFlags(flags)
+	lead, err := l.parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	lead, err = rd.UpsertLead(lead)
+	if err != nil {
+		return err
+	}
+
+	return out.lead(lead)
+}
+
+func deleteLead(e *env, connect connector, out printer, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	err = rd.DeleteLeadByEmail(args[0])
+	if err != nil {
+		return err
+	}
+
+	return out.fields([]string{"deleted"}, map[string]interface{}{"deleted": args[0]})
+}
+
+func addTags(e *env, connect connector, out printer, args []string) error {
+	return changeTags(connect, out, args, rdstation.RDStation.AddTags)
+}
+
+func removeTags(e *env, connect connector, out printer, args []string) error {
+	return changeTags(connect, out, args, rdstation.RDStation.RemoveTags)
+}
+
+func changeTags(connect connector, out printer, args []string, change func(rdstation.RDStation, *entity.Lead, []string) error) error {
+	if len(args) < 2 {
+		return errUsage
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	lead, err := rd.GetLeadByEmail(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = change(rd, lead, args[1:])
+	if err != nil {
+		return err
+	}
+
+	return out.lead(lead)
+}
+
+func convert(e *env, connect connector, out printer, args []string) error {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	identifier := flags.String("identifier", "", "conversion identifier")
+	l := addLeadFlags(flags)
+	lead, err := l.parse(flags, args)
+	if err != nil {
+		return err
+	}
+	if *identifier == "" {
+		return errUsage
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	err = rd.SendConversion(entity.NewConversion(*identifier, lead))
+	if err != nil {
+		return err
+	}
+
+	return out.fields([]string{"conversion_identifier", "email"}, map[string]interface{}{
+		"conversion_identifier": *identifier,
+		"email":                 lead.Email,
+	})
+}
+
+func importLeads(e *env, connect connector, out printer, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	delimiter := flags.String("delimiter", ",", "column delimiter")
+	latin1 := flags.Bool("latin1", false, "the file is encoded in latin1")
+	reportPath := flags.String("report", "", "file for the rejected rows, stderr by default")
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || len([]rune(*delimiter)) != 1 {
+		return errUsage
+	}
+
+	file, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report := e.stderr
+	if *reportPath != "" {
+		reportFile, err := os.Create(*reportPath)
+		if err != nil {
+			return err
+		}
+		defer reportFile.Close()
+		report = reportFile
+	}
+
+	config := leadcsv.Config{Delimiter: []rune(*delimiter)[0]}
+	if *latin1 {
+		config.Encoding = leadcsv.Latin1
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	summary, err := leadcsv.Import(rd, file, report, config)
+	if err != nil {
+		return err
+	}
+
+	return out.fields([]string{"imported", "failed"}, map[string]interface{}{
+		"imported": summary.Imported,
+		"failed":   summary.Failed,
+	})
+}
+
+// exportLeads writes the leads to stdout in csv or jsonl, regardless of
+// -output, and reports the failures to stderr.
+func exportLeads(e *env, connect connector, out printer, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "csv", "csv or jsonl")
+	fields := stringList{}
+	flags.Var(&fields, "field", "custom field to export, repeatable")
+	identifiers, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	config := leadcsv.ExportConfig{CustomFields: fields}
+	switch *format {
+	case "csv":
+		config.Format = leadcsv.CSV
+	case "jsonl":
+		config.Format = leadcsv.JSONL
+	default:
+		return errUsage
+	}
+
+	if len(identifiers) == 1 && identifiers[0] == "-" {
+		identifiers = nil
+		scanner := bufio.NewScanner(e.stdin)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				identifiers = append(identifiers, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+	if len(identifiers) == 0 {
+		return errUsage
+	}
+
+	rd, err := connect()
+	if err != nil {
+		return err
+	}
+
+	summary, err := leadcsv.Export(context.Background(), rd, identifiers, e.stdout, config)
+	if err != nil {
+		return err
+	}
+
+	for _, failed := range summary.Failed {
+		fmt.Fprintf(e.stderr, "%s: %v\n", failed.Identifier, failed.Err)
+	}
+	if len(summary.Failed) > 0 {
+		return fmt.Errorf("%d of %d leads failed", len(summary.Failed), len(identifiers))
+	}
+
+	return nil
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
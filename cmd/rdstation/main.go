@@ -0,0 +1,212 @@
+// Command rdstation runs common RD Station operations from the terminal.
+//
+//	rdstation [-config file] [-output json|table] <command> [flags] [args]
+//
+// Credentials come from RDSTATION_CLIENT_ID, RDSTATION_CLIENT_SECRET and
+// RDSTATION_REFRESH_TOKEN, or from a json config file with the client_id,
+// client_secret and refresh_token fields, ~/.config/rdstation/config.json by
+// default. Variables take precedence over the file.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+type env struct {
+	getenv func(string) string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// connect builds the client once the command line has been parsed.
+	connect func(secret entity.Secret) (rdstation.RDStation, error)
+}
+
+// connector builds the client. Commands call it once their arguments are
+// valid, so usage errors need neither credentials nor a token exchange.
+type connector func() (rdstation.RDStation, error)
+
+type command struct {
+	usage string
+	run   func(e *env, connect connector, out printer, args []string) error
+}
+
+var commands = map[string]command{
+	"get":     {"get <email|uuid>", getLead},
+	"create":  {"create -email <email> [lead flags]", createLead},
+	"update":  {"update -email <email> [lead flags]", updateLead},
+	"upsert":  {"upsert -email <email> [lead flags]", upsertLead},
+	"delete":  {"delete <email>", deleteLead},
+	"tag":     {"tag <email> <tag>...", addTags},
+	"untag":   {"untag <email> <tag>...", removeTags},
+	"convert": {"convert -identifier <conversion> -email <email> [lead flags]", convert},
+	"import":  {"import [-delimiter ;] [-latin1] [-report errors.csv] <file.csv>", importLeads},
+	"export":  {"export [-format csv|jsonl] [-field cf_x]... <email|uuid>... (- reads them from stdin)", exportLeads},
+}
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], &env{
+		getenv: os.Getenv,
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		connect: func(secret entity.Secret) (rdstation.RDStation, error) {
+			return rdstation.New(secret.ClientID, secret.ClientSecret, secret.RefreshToken)
+		},
+	}))
+}
+
+func run(args []string, e *env) int {
+	flags := flag.NewFlagSet("rdstation", flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	configPath := flags.String("config", "", "json file with client_id, client_secret and refresh_token")
+	output := flags.String("output", "json", "output format, json or table")
+	flags.Usage = func() { usage(e.stderr, flags) }
+
+	if flags.Parse(args) != nil {
+		return 2
+	}
+
+	name := flags.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		if name != "" {
+			fmt.Fprintf(e.stderr, "unknown command %q\n", name)
+		}
+		usage(e.stderr, flags)
+		return 2
+	}
+
+	out, err := newPrinter(*output, e.stdout)
+	if err != nil {
+		fmt.Fprintln(e.stderr, err)
+		return 2
+	}
+
+	connect := func() (rdstation.RDStation, error) {
+		secret, err := loadSecret(*configPath, e.getenv)
+		if err != nil {
+			return nil, err
+		}
+
+		return e.connect(secret)
+	}
+
+	err = cmd.run(e, connect, out, flags.Args()[1:])
+	switch {
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(e.stderr, "usage: rdstation %s\n", cmd.usage)
+		return 2
+	case err != nil:
+		fmt.Fprintln(e.stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func usage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintln(w, "usage: rdstation [-config file] [-output json|table] <command> [flags] [args]")
+	fmt.Fprintln(w, "\ncommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", commands[name].usage)
+	}
+
+	fmt.Fprintln(w, "\nflags:")
+	flags.PrintDefaults()
+}
+
+// loadSecret reads the config file, when there is one, and overrides it with
+// the environment.
+func loadSecret(path string, getenv func(string) string) (entity.Secret, error) {
+	var secret entity.Secret
+
+	explicit := path != "" || getenv("RDSTATION_CONFIG") != ""
+	if path == "" {
+		path = getenv("RDSTATION_CONFIG")
+	}
+	if path == "" {
+		if home := getenv("HOME"); home != "" {
+			path = filepath.Join(home, ".config", "rdstation", "config.json")
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			err = json.Unmarshal(data, &secret)
+			if err != nil {
+				return secret, fmt.Errorf("config %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, os.ErrNotExist):
+			return secret, err
+		}
+	}
+
+	for variable, field := range map[string]*string{
+		"RDSTATION_CLIENT_ID":     &secret.ClientID,
+		"RDSTATION_CLIENT_SECRET": &secret.ClientSecret,
+		"RDSTATION_REFRESH_TOKEN": &secret.RefreshToken,
+	} {
+		if value := getenv(variable); value != "" {
+			*field = value
+		}
+	}
+
+	if secret.ClientID == "" || secret.ClientSecret == "" || secret.RefreshToken == "" {
+		return secret, errors.New("missing credentials, set RDSTATION_CLIENT_ID, RDSTATION_CLIENT_SECRET and RDSTATION_REFRESH_TOKEN or use -config")
+	}
+
+	return secret, nil
+}
+
+// parse parses the flags of a command, which may come before or after its
+// arguments.
+func parse(flags *flag.FlagSet, args []string) ([]string, error) {
+	flags.SetOutput(io.Discard)
+
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
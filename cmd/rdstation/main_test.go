@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/rdstationtest"
+)
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+func runWith(memory *rdstationtest.Memory, vars map[string]string, stdin string, args ...string) result {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &env{
+		getenv: func(name string) string { return vars[name] },
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		connect: func(secret entity.Secret) (rdstation.RDStation, error) {
+			return memory, nil
+		},
+	})
+
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+var credentials = map[string]string{
+	"RDSTATION_CLIENT_ID":     "id",
+	"RDSTATION_CLIENT_SECRET": "secret",
+	"RDSTATION_REFRESH_TOKEN": "refresh",
+}
+
+func TestRun_Leads(t *testing.T) {
+	memory := rdstationtest.NewMemory()
+
+	res := runWith(memory, credentials, "", "create", "-email", "batata@example.com", "-name", "Batata", "-tags", "a, b", "-field", "cf_plano=ouro")
+	require.Equal(t, 0, res.code, res.stderr)
+	assert.Contains(t, res.stdout, `"cf_plano": "ouro"`)
+
+	lead, ok := memory.Lead("batata@example.com")
+	require.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, lead.Tags)
+
+	res = runWith(memory, credentials, "", "update", "-email", "batata@example.com", "-city", "Curitiba")
+	require.Equal(t, 0, res.code, res.stderr)
+	assert.Contains(t, res.stdout, `"city": "Curitiba"`)
+
+	res = runWith(memory, credentials, "", "tag", "batata@example.com", "c")
+	require.Equal(t, 0, res.code, res.stderr)
+
+	res = runWith(memory, credentials, "", "-output", "table", "untag", "batata@example.com", "a")
+	require.Equal(t, 0, res.code, res.stderr)
+	assert.Regexp(t, `(?m)^email\s+batata@example.com$`, res.stdout)
+	assert.Regexp(t, `(?m)^tags\s+b,c$`, res.stdout)
+
+	res = runWith(memory, credentials, "", "convert", "-identifier", "cadastro", "-email", "nova@example.com")
+	require.Equal(t, 0, res.code, res.stderr)
+	_, ok = memory.Lead("nova@example.com")
+	assert.True(t, ok)
+
+	res = runWith(memory, credentials, "batata@example.com\nnenhum@example.com\n", "export", "-field", "cf_plano", "-")
+	assert.Equal(t, 1, res.code)
+	assert.Contains(t, res.stdout, "cf_plano")
+	assert.Contains(t, res.stdout, "ouro")
+	assert.Contains(t, res.stderr, "nenhum@example.com")
+
+	res = runWith(memory, credentials, "", "delete", "batata@example.com")
+	require.Equal(t, 0, res.code, res.stderr)
+
+	res = runWith(memory, credentials, "", "get", "batata@example.com")
+	assert.Equal(t, 1, res.code)
+	assert.Contains(t, res.stderr, "404")
+}
+
+func TestRun_Import(t *testing.T) {
+	memory := rdstationtest.NewMemory()
+
+	path := filepath.Join(t.TempDir(), "leads.csv")
+	require.NoError(t, os.WriteFile(path, []byte("email;name\nbatata@example.com;Batata\ninvalido;Nada\n"), 0o644))
+
+	res := runWith(memory, credentials, "", "-output", "table", "import", "-delimiter", ";", path)
+	require.Equal(t, 0, res.code, res.stderr)
+	assert.Regexp(t, `(?m)^imported\s+1$`, res.stdout)
+	assert.Regexp(t, `(?m)^failed\s+1$`, res.stdout)
+	assert.Contains(t, res.stderr, "invalido")
+}
+
+func TestRun_Usage(t *testing.T) {
+	memory := rdstationtest.NewMemory()
+
+	assert.Equal(t, 2, runWith(memory, credentials, "").code)
+	assert.Equal(t, 2, runWith(memory, credentials, "", "batata").code)
+	assert.Equal(t, 2, runWith(memory, credentials, "", "create", "-name", "Batata").code)
+	assert.Equal(t, 2, runWith(memory, credentials, "", "-output", "xml", "get", "x").code)
+
+	res := runWith(memory, map[string]string{}, "", "get", "batata@example.com")
+	assert.Equal(t, 1, res.code)
+	assert.Contains(t, res.stderr, "missing credentials")
+
+	// arguments are checked before the credentials are loaded or exchanged
+	connected := false
+	for _, args := range [][]string{
+		{"get"},
+		{"create", "-name", "Batata"},
+		{"tag", "batata@example.com"},
+		{"convert", "-email", "batata@example.com"},
+		{"import", "-delimiter", ";;", "leads.csv"},
+		{"export", "-format", "xml", "batata@example.com"},
+	} {
+		code := run(args, &env{
+			getenv: func(string) string { return "" },
+			stdin:  strings.NewReader(""),
+			stdout: &bytes.Buffer{},
+			stderr: &bytes.Buffer{},
+			connect: func(secret entity.Secret) (rdstation.RDStation, error) {
+				connected = true
+				return memory, nil
+			},
+		})
+		assert.Equal(t, 2, code, args)
+	}
+	assert.False(t, connected)
+}
+
+func TestLoadSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"client_id":"id","client_secret":"secret","refresh_token":"refresh"}`), 0o600))
+
+	secret, err := loadSecret(path, func(name string) string {
+		if name == "RDSTATION_REFRESH_TOKEN" {
+			return "outro"
+		}
+		return ""
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.Secret{ClientID: "id", ClientSecret: "secret", RefreshToken: "outro"}, secret)
+
+	_, err = loadSecret(filepath.Join(t.TempDir(), "nenhum.json"), func(string) string { return "" })
+	assert.Error(t, err)
+}
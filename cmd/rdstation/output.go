@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/leadcsv"
+)
+
+type printer struct {
+	table bool
+	w     io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (printer, error) {
+	switch format {
+	case "json":
+		return printer{w: w}, nil
+	case "table":
+		return printer{table: true, w: w}, nil
+	default:
+		return printer{}, fmt.Errorf("unknown output %q, use json or table", format)
+	}
+}
+
+func (p printer) lead(lead *entity.Lead) error {
+	if !p.table {
+		return p.json(lead)
+	}
+
+	data, err := json.Marshal(lead)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{}
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return err
+	}
+
+	order := make([]string, 0, len(values))
+	for _, column := range leadcsv.Columns {
+		if _, ok := values[column]; ok {
+			order = append(order, column)
+		}
+	}
+
+	var custom []string
+	for name := range lead.CustomFields {
+		custom = append(custom, name)
+	}
+	sort.Strings(custom)
+
+	return p.fields(append(order, custom...), values)
+}
+
+// fields prints values in json, or as a two column table in order.
+func (p printer) fields(order []string, values map[string]interface{}) error {
+	if !p.table {
+		return p.json(values)
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	for _, name := range order {
+		fmt.Fprintf(w, "%s\t%s\n", name, cell(values[name]))
+	}
+
+	return w.Flush()
+}
+
+func (p printer) json(v interface{}) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func cell(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,23 @@
+package crm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/flan6/rdstation"
+)
+
+func (c crm) ListActivities(ctx context.Context, dealID string) *rdstation.Iterator[Activity] {
+	return pages(ctx, c, ActivitiesPath, url.Values{"deal_id": {dealID}}, listOf[Activity]("activities"))
+}
+
+func (c crm) CreateActivity(activity *Activity) (*Activity, error) {
+	var created Activity
+	err := c.do(http.MethodPost, c.url(ActivitiesPath, nil), map[string]*Activity{"activity": activity}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
@@ -0,0 +1,65 @@
+package crm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/flan6/rdstation"
+)
+
+type ContactQuery struct {
+	Email string
+	Phone string
+	// Query searches contacts by name.
+	Query string
+}
+
+func (q ContactQuery) values() url.Values {
+	values := url.Values{}
+	if q.Email != "" {
+		values.Set("email", q.Email)
+	}
+	if q.Phone != "" {
+		values.Set("phone", q.Phone)
+	}
+	if q.Query != "" {
+		values.Set("q", q.Query)
+	}
+
+	return values
+}
+
+func (c crm) ListContacts(ctx context.Context, query ContactQuery) *rdstation.Iterator[Contact] {
+	return pages(ctx, c, ContactsPath, query.values(), listOf[Contact]("contacts"))
+}
+
+func (c crm) GetContact(id string) (*Contact, error) {
+	var contact Contact
+	err := c.get(c.url(ContactsPath+"/"+id, nil), &contact)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contact, nil
+}
+
+func (c crm) CreateContact(contact *Contact) (*Contact, error) {
+	var created Contact
+	err := c.do(http.MethodPost, c.url(ContactsPath, nil), map[string]*Contact{"contact": contact}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c crm) UpdateContact(contact *Contact) (*Contact, error) {
+	var updated Contact
+	err := c.do(http.MethodPut, c.url(ContactsPath+"/"+contact.ID, nil), map[string]*Contact{"contact": contact}, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
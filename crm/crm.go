@@ -0,0 +1,203 @@
+// Package crm is a client for the RD Station CRM api, which is separate
+// from the Marketing api and authenticated by an account token.
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/internal/client"
+)
+
+const (
+	CRMURL = "https://crm.rdstation.com/api/v1/"
+
+	DealsPath         = "deals"
+	OrganizationsPath = "organizations"
+	ContactsPath      = "contacts"
+	DealStagesPath    = "deal_stages"
+	PipelinesPath     = "deal_pipelines"
+	ActivitiesPath    = "activities"
+	TasksPath         = "tasks"
+	ProductsPath      = "products"
+	UsersPath         = "users"
+)
+
+// MaxPageSize is the largest page the CRM list endpoints return.
+const MaxPageSize = 200
+
+type CRM interface {
+	ListDeals(ctx context.Context, query DealQuery) *rdstation.Iterator[Deal]
+	GetDeal(id string) (*Deal, error)
+	CreateDeal(deal *NewDeal) (*Deal, error)
+	UpdateDeal(deal *Deal) (*Deal, error)
+
+	ListOrganizations(ctx context.Context, query OrganizationQuery) *rdstation.Iterator[Organization]
+	GetOrganization(id string) (*Organization, error)
+	CreateOrganization(organization *Organization) (*Organization, error)
+	UpdateOrganization(organization *Organization) (*Organization, error)
+
+	ListContacts(ctx context.Context, query ContactQuery) *rdstation.Iterator[Contact]
+	GetContact(id string) (*Contact, error)
+	CreateContact(contact *Contact) (*Contact, error)
+	UpdateContact(contact *Contact) (*Contact, error)
+
+	ListPipelines() ([]Pipeline, error)
+	ListDealStages(pipelineID string) ([]DealStage, error)
+
+	ListActivities(ctx context.Context, dealID string) *rdstation.Iterator[Activity]
+	CreateActivity(activity *Activity) (*Activity, error)
+
+	ListTasks(ctx context.Context, query TaskQuery) *rdstation.Iterator[Task]
+	GetTask(id string) (*Task, error)
+	CreateTask(task *Task) (*Task, error)
+	UpdateTask(task *Task) (*Task, error)
+
+	ListProducts(ctx context.Context) *rdstation.Iterator[Product]
+	GetProduct(id string) (*Product, error)
+	CreateProduct(product *Product) (*Product, error)
+	UpdateProduct(product *Product) (*Product, error)
+
+	ListUsers() ([]User, error)
+}
+
+type crm struct {
+	client  client.Client
+	baseURL string
+}
+
+type Option func(*options)
+
+type options struct {
+	httpClient *http.Client
+	baseURL    string
+	limiter    *client.Limiter
+	attempts   int
+	backoff    time.Duration
+}
+
+// WithHTTPClient sends every request through httpClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithBaseURL points the client to another server, such as a test double.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithRateLimit makes the client start at most requests calls per period.
+func WithRateLimit(requests int, per time.Duration) Option {
+	return func(o *options) {
+		o.limiter = client.NewLimiter(requests, per)
+	}
+}
+
+// WithRetry retries retriable errors like rdstation.WithRetry does.
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.attempts = attempts
+		o.backoff = backoff
+	}
+}
+
+// NewCRM returns a client authenticated by the account api token, found in
+// the CRM integration settings.
+func NewCRM(token string, opts ...Option) CRM {
+	o := options{
+		httpClient: http.DefaultClient,
+		baseURL:    CRMURL,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cl := client.NewTokenClient(o.httpClient, token)
+	if o.limiter != nil {
+		cl = client.WithLimiter(cl, o.limiter)
+	}
+	if o.attempts > 1 {
+		cl = client.WithRetry(cl, o.attempts, o.backoff)
+	}
+
+	return &crm{
+		client:  cl,
+		baseURL: o.baseURL,
+	}
+}
+
+func (c crm) url(path string, query url.Values) string {
+	if len(query) == 0 {
+		return c.baseURL + path
+	}
+
+	return fmt.Sprintf("%s%s?%s", c.baseURL, path, query.Encode())
+}
+
+func (c crm) do(method, url string, body interface{}, v interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := c.client.Request(url, method, data)
+	if err != nil || v == nil {
+		return err
+	}
+
+	return json.Unmarshal(result, v)
+}
+
+func (c crm) get(url string, v interface{}) error {
+	return c.do(http.MethodGet, url, nil, v)
+}
+
+// pages iterates over a list endpoint, decoding each page with decode.
+func pages[T any](ctx context.Context, c crm, path string, query url.Values, decode func([]byte) ([]T, error)) *rdstation.Iterator[T] {
+	return rdstation.NewPageIterator(ctx, MaxPageSize, func(ctx context.Context, page, pageSize int) ([]T, error) {
+		q := url.Values{}
+		for key, values := range query {
+			q[key] = values
+		}
+		q.Set("page", fmt.Sprint(page))
+		q.Set("limit", fmt.Sprint(pageSize))
+
+		data, err := c.client.Request(c.url(path, q), http.MethodGet, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return decode(data)
+	})
+}
+
+// listOf decodes pages wrapping the items in a key, as most list endpoints do.
+func listOf[T any](key string) func([]byte) ([]T, error) {
+	return func(data []byte) ([]T, error) {
+		var response map[string]json.RawMessage
+		err := json.Unmarshal(data, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []T
+		if raw, ok := response[key]; ok {
+			err = json.Unmarshal(raw, &items)
+		}
+
+		return items, err
+	}
+}
@@ -0,0 +1,122 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+)
+
+func newTestCRM() (CRM, *httpmock.MockTransport) {
+	transport := httpmock.NewMockTransport()
+	return NewCRM("batata", WithHTTPClient(&http.Client{Transport: transport})), transport
+}
+
+func TestCRM_ListDeals(t *testing.T) {
+	c, transport := newTestCRM()
+
+	var pages []string
+	transport.RegisterResponder(http.MethodGet, CRMURL+DealsPath,
+		func(r *http.Request) (*http.Response, error) {
+			query := r.URL.Query()
+			assert.Equal(t, "batata", query.Get("token"))
+			assert.Equal(t, "stage", query.Get("deal_stage_id"))
+			assert.Equal(t, fmt.Sprint(MaxPageSize), query.Get("limit"))
+			pages = append(pages, query.Get("page"))
+
+			deals := []Deal{{ID: "2", Name: "second"}}
+			if query.Get("page") == "1" {
+				deals = make([]Deal, MaxPageSize)
+				for i := range deals {
+					deals[i] = Deal{ID: fmt.Sprint("d", i)}
+				}
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, map[string]interface{}{"deals": deals, "has_more": false})
+		})
+
+	deals, err := rdstation.Collect(c.ListDeals(context.Background(), DealQuery{DealStageID: "stage"}), 0)
+	require.NoError(t, err)
+	assert.Len(t, deals, MaxPageSize+1)
+	assert.Equal(t, "second", deals[MaxPageSize].Name)
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
+
+func TestCRM_CreateDeal(t *testing.T) {
+	c, transport := newTestCRM()
+
+	transport.RegisterResponder(http.MethodPost, CRMURL+DealsPath,
+		func(r *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{
+				"deal": {"name": "batata", "deal_stage_id": "stage"},
+				"organization": {"_id": "org"},
+				"contacts": [{"name": "Batata", "emails": [{"email": "batata@example.com"}]}]
+			}`, string(body))
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"id":"deal","name":"batata"}`), nil
+		})
+
+	deal, err := c.CreateDeal(&NewDeal{
+		Deal:         Deal{Name: "batata", DealStageID: "stage"},
+		Organization: &Reference{ID: "org"},
+		Contacts:     []Contact{{Name: "Batata", Emails: []Email{{Email: "batata@example.com"}}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "deal", deal.ID)
+}
+
+func TestCRM_UpdateContact(t *testing.T) {
+	c, transport := newTestCRM()
+
+	transport.RegisterResponder(http.MethodPut, CRMURL+ContactsPath+"/c1",
+		func(r *http.Request) (*http.Response, error) {
+			var body map[string]Contact
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Batata", body["contact"].Name)
+
+			return httpmock.NewJsonResponse(http.StatusOK, body["contact"])
+		})
+
+	contact, err := c.UpdateContact(&Contact{ID: "c1", Name: "Batata"})
+	require.NoError(t, err)
+	assert.Equal(t, "c1", contact.ID)
+}
+
+func TestCRM_ListDealStages(t *testing.T) {
+	c, transport := newTestCRM()
+
+	transport.RegisterResponder(http.MethodGet, CRMURL+DealStagesPath,
+		func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "pipe", r.URL.Query().Get("deal_pipeline_id"))
+			return httpmock.NewStringResponse(http.StatusOK,
+				`{"deal_stages":[{"id":"s1","name":"Lead","order":1}]}`), nil
+		})
+
+	stages, err := c.ListDealStages("pipe")
+	require.NoError(t, err)
+	assert.Equal(t, []DealStage{{ID: "s1", Name: "Lead", Order: 1}}, stages)
+}
+
+func TestCRM_Error(t *testing.T) {
+	c, transport := newTestCRM()
+
+	transport.RegisterResponder(http.MethodGet, CRMURL+OrganizationsPath+"/missing",
+		httpmock.NewStringResponder(http.StatusNotFound, "Not Found"))
+
+	_, err := c.GetOrganization("missing")
+
+	var rdErr rdstation.RDError
+	require.True(t, errors.As(err, &rdErr))
+	assert.Equal(t, http.StatusNotFound, rdErr.Errors.StatusCode)
+	assert.Equal(t, "NOT_FOUND", rdErr.Errors.Type)
+}
@@ -0,0 +1,69 @@
+package crm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/flan6/rdstation"
+)
+
+type DealQuery struct {
+	Name        string
+	UserID      string
+	DealStageID string
+	Win         *bool
+}
+
+func (q DealQuery) values() url.Values {
+	values := url.Values{}
+	if q.Name != "" {
+		values.Set("name", q.Name)
+	}
+	if q.UserID != "" {
+		values.Set("user_id", q.UserID)
+	}
+	if q.DealStageID != "" {
+		values.Set("deal_stage_id", q.DealStageID)
+	}
+	if q.Win != nil {
+		values.Set("win", fmt.Sprint(*q.Win))
+	}
+
+	return values
+}
+
+func (c crm) ListDeals(ctx context.Context, query DealQuery) *rdstation.Iterator[Deal] {
+	return pages(ctx, c, DealsPath, query.values(), listOf[Deal]("deals"))
+}
+
+func (c crm) GetDeal(id string) (*Deal, error) {
+	var deal Deal
+	err := c.get(c.url(DealsPath+"/"+id, nil), &deal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deal, nil
+}
+
+func (c crm) CreateDeal(deal *NewDeal) (*Deal, error) {
+	var created Deal
+	err := c.do(http.MethodPost, c.url(DealsPath, nil), deal, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c crm) UpdateDeal(deal *Deal) (*Deal, error) {
+	var updated Deal
+	err := c.do(http.MethodPut, c.url(DealsPath+"/"+deal.ID, nil), map[string]*Deal{"deal": deal}, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
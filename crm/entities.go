@@ -0,0 +1,139 @@
+package crm
+
+type User struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Nickname string `json:"nickname,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Active   bool   `json:"active,omitempty"`
+}
+
+type CustomField struct {
+	CustomFieldID string      `json:"custom_field_id"`
+	Value         interface{} `json:"value"`
+}
+
+type Organization struct {
+	ID                       string        `json:"id,omitempty"`
+	Name                     string        `json:"name"`
+	Resume                   string        `json:"resume,omitempty"`
+	URL                      string        `json:"url,omitempty"`
+	UserID                   string        `json:"user_id,omitempty"`
+	User                     *User         `json:"user,omitempty"`
+	OrganizationCustomFields []CustomField `json:"organization_custom_fields,omitempty"`
+	CreatedAt                string        `json:"created_at,omitempty"`
+	UpdatedAt                string        `json:"updated_at,omitempty"`
+}
+
+type Email struct {
+	Email string `json:"email"`
+}
+
+type Phone struct {
+	Phone string `json:"phone"`
+	Type  string `json:"type,omitempty"`
+}
+
+type Contact struct {
+	ID                  string        `json:"id,omitempty"`
+	Name                string        `json:"name"`
+	Title               string        `json:"title,omitempty"`
+	Notes               string        `json:"notes,omitempty"`
+	Emails              []Email       `json:"emails,omitempty"`
+	Phones              []Phone       `json:"phones,omitempty"`
+	OrganizationID      string        `json:"organization_id,omitempty"`
+	DealIDs             []string      `json:"deal_ids,omitempty"`
+	ContactCustomFields []CustomField `json:"contact_custom_fields,omitempty"`
+	CreatedAt           string        `json:"created_at,omitempty"`
+	UpdatedAt           string        `json:"updated_at,omitempty"`
+}
+
+type DealStage struct {
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name"`
+	Nickname   string `json:"nickname,omitempty"`
+	Order      int    `json:"order,omitempty"`
+	PipelineID string `json:"deal_pipeline_id,omitempty"`
+}
+
+type Pipeline struct {
+	ID         string      `json:"id,omitempty"`
+	Name       string      `json:"name"`
+	DealStages []DealStage `json:"deal_stages,omitempty"`
+}
+
+type Deal struct {
+	ID               string        `json:"id,omitempty"`
+	Name             string        `json:"name"`
+	AmountTotal      float64       `json:"amount_total,omitempty"`
+	Rating           int           `json:"rating,omitempty"`
+	Win              *bool         `json:"win,omitempty"`
+	Hold             *bool         `json:"hold,omitempty"`
+	DealStageID      string        `json:"deal_stage_id,omitempty"`
+	DealStage        *DealStage    `json:"deal_stage,omitempty"`
+	UserID           string        `json:"user_id,omitempty"`
+	User             *User         `json:"user,omitempty"`
+	Organization     *Organization `json:"organization,omitempty"`
+	Contacts         []Contact     `json:"contacts,omitempty"`
+	DealCustomFields []CustomField `json:"deal_custom_fields,omitempty"`
+	PredictionDate   string        `json:"prediction_date,omitempty"`
+	ClosedAt         string        `json:"closed_at,omitempty"`
+	CreatedAt        string        `json:"created_at,omitempty"`
+	UpdatedAt        string        `json:"updated_at,omitempty"`
+}
+
+// Reference points to an existing record by id.
+type Reference struct {
+	ID string `json:"_id"`
+}
+
+// NewDeal is the payload to create a deal along with the records it links.
+type NewDeal struct {
+	Deal         Deal       `json:"deal"`
+	Organization *Reference `json:"organization,omitempty"`
+	Contacts     []Contact  `json:"contacts,omitempty"`
+	DealSource   *Reference `json:"deal_source,omitempty"`
+	Campaign     *Reference `json:"campaign,omitempty"`
+}
+
+type Activity struct {
+	ID     string `json:"id,omitempty"`
+	DealID string `json:"deal_id"`
+	UserID string `json:"user_id,omitempty"`
+	Text   string `json:"text"`
+	Date   string `json:"date,omitempty"`
+}
+
+// Task types accepted by the CRM.
+const (
+	TaskCall     = "call"
+	TaskEmail    = "email"
+	TaskMeeting  = "meeting"
+	TaskTask     = "task"
+	TaskLunch    = "lunch"
+	TaskVisit    = "visit"
+	TaskWhatsApp = "whatsapp"
+)
+
+type Task struct {
+	ID      string   `json:"id,omitempty"`
+	Subject string   `json:"subject"`
+	Type    string   `json:"type,omitempty"`
+	Date    string   `json:"date,omitempty"`
+	Hour    string   `json:"hour,omitempty"`
+	DealID  string   `json:"deal_id,omitempty"`
+	UserIDs []string `json:"user_ids,omitempty"`
+	Notes   string   `json:"notes,omitempty"`
+	Done    bool     `json:"done,omitempty"`
+	DoneAt  string   `json:"done_date,omitempty"`
+}
+
+type Product struct {
+	ID          string  `json:"id,omitempty"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	BasePrice   float64 `json:"base_price,omitempty"`
+	Visible     *bool   `json:"visible,omitempty"`
+	CreatedAt   string  `json:"created_at,omitempty"`
+	UpdatedAt   string  `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,160 @@
+package crm
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+var ErrNoDealStage = errors.New("deal stage is required")
+
+type LinkOptions struct {
+	// DealStageID is the pipeline stage the deal is created in.
+	DealStageID string
+	// DealName defaults to the lead name, or its email when it has none.
+	DealName string
+	// OrganizationName links the contact and the deal to an organization,
+	// found by name or created.
+	OrganizationName string
+	// UserID owns the created records, the token owner when empty.
+	UserID      string
+	AmountTotal float64
+	// LifecycleStage moves the lead in the marketing funnel as well, such as
+	// entity.LifecycleClient. The stage is kept when empty.
+	LifecycleStage string
+}
+
+type LinkResult struct {
+	ContactID           string
+	ContactCreated      bool
+	OrganizationID      string
+	OrganizationCreated bool
+	DealID              string
+	Funnel              *entity.Funnel
+}
+
+// LinkLead turns a marketing lead into a CRM deal: it finds or creates the
+// CRM contact by email and the organization by name, creates the deal and
+// marks the lead as an opportunity in the marketing funnel.
+//
+// The steps are not atomic. On error the result holds the ids of whatever was
+// already created, so the caller can resume or clean up.
+func LinkLead(ctx context.Context, rd rdstation.FunnelService, c CRM, lead *entity.Lead, opts LinkOptions) (*LinkResult, error) {
+	result := &LinkResult{}
+	if opts.DealStageID == "" {
+		return result, ErrNoDealStage
+	}
+
+	if opts.OrganizationName != "" {
+		organization, created, err := findOrCreateOrganization(ctx, c, opts.OrganizationName, opts.UserID)
+		if err != nil {
+			return result, err
+		}
+		result.OrganizationID, result.OrganizationCreated = organization.ID, created
+	}
+
+	contact, created, err := findOrCreateContact(ctx, c, lead, result.OrganizationID)
+	if err != nil {
+		return result, err
+	}
+	result.ContactID, result.ContactCreated = contact.ID, created
+
+	deal := &NewDeal{Deal: Deal{
+		Name:        dealName(lead, opts.DealName),
+		DealStageID: opts.DealStageID,
+		UserID:      opts.UserID,
+		AmountTotal: opts.AmountTotal,
+	}}
+	if result.OrganizationID != "" {
+		deal.Organization = &Reference{ID: result.OrganizationID}
+	}
+
+	newDeal, err := c.CreateDeal(deal)
+	if err != nil {
+		return result, err
+	}
+	result.DealID = newDeal.ID
+
+	// An existing contact without organization joins the one of the deal, one
+	// that already belongs to another organization keeps it.
+	if contact.OrganizationID == "" {
+		contact.OrganizationID = result.OrganizationID
+	}
+	contact.DealIDs = append(contact.DealIDs, result.DealID)
+	_, err = c.UpdateContact(&Contact{
+		ID:             contact.ID,
+		Name:           contact.Name,
+		OrganizationID: contact.OrganizationID,
+		DealIDs:        contact.DealIDs,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Funnel, err = rd.UpdateFunnel(lead.Email, &entity.Funnel{
+		LifecycleStage: opts.LifecycleStage,
+		Opportunity:    true,
+	})
+
+	return result, err
+}
+
+func dealName(lead *entity.Lead, name string) string {
+	switch {
+	case name != "":
+		return name
+	case lead.Name != "":
+		return lead.Name
+	default:
+		return lead.Email
+	}
+}
+
+func findOrCreateOrganization(ctx context.Context, c CRM, name, userID string) (*Organization, bool, error) {
+	it := c.ListOrganizations(ctx, OrganizationQuery{Query: name})
+	for it.Next() {
+		organization := it.Value()
+		if strings.EqualFold(organization.Name, name) {
+			return &organization, false, nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+
+	organization, err := c.CreateOrganization(&Organization{Name: name, UserID: userID})
+
+	return organization, err == nil, err
+}
+
+func findOrCreateContact(ctx context.Context, c CRM, lead *entity.Lead, organizationID string) (*Contact, bool, error) {
+	contacts, err := rdstation.Collect(c.ListContacts(ctx, ContactQuery{Email: lead.Email}), 1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(contacts) > 0 {
+		return &contacts[0], false, nil
+	}
+
+	contact := &Contact{
+		Name:           lead.Name,
+		Title:          lead.JobTitle,
+		Emails:         []Email{{Email: lead.Email}},
+		OrganizationID: organizationID,
+	}
+	if contact.Name == "" {
+		contact.Name = lead.Email
+	}
+	for _, phone := range []string{lead.MobilePhone, lead.PersonalPhone} {
+		if phone != "" {
+			contact.Phones = append(contact.Phones, Phone{Phone: phone})
+		}
+	}
+
+	contact, err = c.CreateContact(contact)
+
+	return contact, err == nil, err
+}
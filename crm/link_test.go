@@ -0,0 +1,133 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type fakeFunnels struct {
+	email  string
+	funnel *entity.Funnel
+}
+
+func (f *fakeFunnels) GetFunnel(email string) (*entity.Funnel, error) {
+	return f.funnel, nil
+}
+
+func (f *fakeFunnels) UpdateFunnel(email string, funnel *entity.Funnel) (*entity.Funnel, error) {
+	f.email, f.funnel = email, funnel
+	return funnel, nil
+}
+
+func TestLinkLead(t *testing.T) {
+	c, transport := newTestCRM()
+
+	transport.RegisterResponder(http.MethodGet, CRMURL+OrganizationsPath,
+		httpmock.NewStringResponder(http.StatusOK, `{"organizations":[{"id":"o1","name":"Batatas"}]}`))
+	transport.RegisterResponder(http.MethodPost, CRMURL+OrganizationsPath,
+		httpmock.NewStringResponder(http.StatusOK, `{"id":"o2","name":"Batatas LTDA"}`))
+	transport.RegisterResponder(http.MethodGet, CRMURL+ContactsPath,
+		httpmock.NewStringResponder(http.StatusOK, `{"contacts":[]}`))
+
+	var contact Contact
+	transport.RegisterResponder(http.MethodPost, CRMURL+ContactsPath,
+		func(r *http.Request) (*http.Response, error) {
+			var body map[string]Contact
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			contact = body["contact"]
+			contact.ID = "c1"
+			return httpmock.NewJsonResponse(http.StatusOK, contact)
+		})
+
+	var deal NewDeal
+	transport.RegisterResponder(http.MethodPost, CRMURL+DealsPath,
+		func(r *http.Request) (*http.Response, error) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&deal))
+			return httpmock.NewStringResponse(http.StatusOK, `{"id":"d1"}`), nil
+		})
+
+	var linked Contact
+	transport.RegisterResponder(http.MethodPut, CRMURL+ContactsPath+"/c1",
+		func(r *http.Request) (*http.Response, error) {
+			var body map[string]Contact
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			linked = body["contact"]
+			return httpmock.NewJsonResponse(http.StatusOK, linked)
+		})
+
+	funnels := &fakeFunnels{}
+	lead := &entity.Lead{Name: "Batata", Email: "batata@example.com", MobilePhone: "11999999999"}
+
+	result, err := LinkLead(context.Background(), funnels, c, lead, LinkOptions{
+		DealStageID:      "stage",
+		OrganizationName: "batatas ltda",
+		LifecycleStage:   entity.LifecycleClient,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &LinkResult{
+		ContactID:           "c1",
+		ContactCreated:      true,
+		OrganizationID:      "o2",
+		OrganizationCreated: true,
+		DealID:              "d1",
+		Funnel:              &entity.Funnel{LifecycleStage: entity.LifecycleClient, Opportunity: true},
+	}, result)
+
+	assert.Equal(t, "o2", contact.OrganizationID)
+	assert.Equal(t, []Phone{{Phone: "11999999999"}}, contact.Phones)
+	assert.Equal(t, "Batata", deal.Deal.Name)
+	assert.Equal(t, "stage", deal.Deal.DealStageID)
+	assert.Equal(t, &Reference{ID: "o2"}, deal.Organization)
+	assert.Equal(t, []string{"d1"}, linked.DealIDs)
+	assert.Equal(t, "batata@example.com", funnels.email)
+
+	t.Run("existing contact", func(t *testing.T) {
+		transport.RegisterResponder(http.MethodGet, CRMURL+ContactsPath,
+			httpmock.NewStringResponder(http.StatusOK, `{"contacts":[{"id":"c1","name":"Batata","deal_ids":["d0"]}]}`))
+
+		result, err := LinkLead(context.Background(), funnels, c, lead, LinkOptions{DealStageID: "stage"})
+		require.NoError(t, err)
+		assert.False(t, result.ContactCreated)
+		assert.Empty(t, result.OrganizationID)
+		assert.Equal(t, []string{"d0", "d1"}, linked.DealIDs)
+		assert.Empty(t, linked.OrganizationID)
+
+		result, err = LinkLead(context.Background(), funnels, c, lead, LinkOptions{DealStageID: "stage", OrganizationName: "Batatas"})
+		require.NoError(t, err)
+		assert.Equal(t, "o1", result.OrganizationID)
+		assert.Equal(t, "o1", linked.OrganizationID)
+	})
+
+	t.Run("contact in another organization", func(t *testing.T) {
+		transport.RegisterResponder(http.MethodGet, CRMURL+ContactsPath,
+			httpmock.NewStringResponder(http.StatusOK, `{"contacts":[{"id":"c1","name":"Batata","organization_id":"o9"}]}`))
+
+		_, err := LinkLead(context.Background(), funnels, c, lead, LinkOptions{DealStageID: "stage", OrganizationName: "Batatas"})
+		require.NoError(t, err)
+		assert.Equal(t, "o9", linked.OrganizationID)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		transport.RegisterResponder(http.MethodPost, CRMURL+DealsPath,
+			httpmock.NewStringResponder(http.StatusUnprocessableEntity, `{"errors":{"deal_stage_id":["inválido"]}}`))
+
+		result, err := LinkLead(context.Background(), funnels, c, lead, LinkOptions{DealStageID: "stage"})
+		require.Error(t, err)
+		assert.Equal(t, "c1", result.ContactID)
+		assert.Empty(t, result.DealID)
+	})
+
+	t.Run("no stage", func(t *testing.T) {
+		_, err := LinkLead(context.Background(), funnels, c, lead, LinkOptions{})
+		assert.True(t, errors.Is(err, ErrNoDealStage))
+	})
+}
@@ -0,0 +1,61 @@
+package crm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/flan6/rdstation"
+)
+
+type OrganizationQuery struct {
+	// Query searches organizations by name.
+	Query  string
+	UserID string
+}
+
+func (q OrganizationQuery) values() url.Values {
+	values := url.Values{}
+	if q.Query != "" {
+		values.Set("q", q.Query)
+	}
+	if q.UserID != "" {
+		values.Set("user_id", q.UserID)
+	}
+
+	return values
+}
+
+func (c crm) ListOrganizations(ctx context.Context, query OrganizationQuery) *rdstation.Iterator[Organization] {
+	return pages(ctx, c, OrganizationsPath, query.values(), listOf[Organization]("organizations"))
+}
+
+func (c crm) GetOrganization(id string) (*Organization, error) {
+	var organization Organization
+	err := c.get(c.url(OrganizationsPath+"/"+id, nil), &organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+func (c crm) CreateOrganization(organization *Organization) (*Organization, error) {
+	var created Organization
+	err := c.do(http.MethodPost, c.url(OrganizationsPath, nil), map[string]*Organization{"organization": organization}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c crm) UpdateOrganization(organization *Organization) (*Organization, error) {
+	var updated Organization
+	err := c.do(http.MethodPut, c.url(OrganizationsPath+"/"+organization.ID, nil), map[string]*Organization{"organization": organization}, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
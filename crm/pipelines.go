@@ -0,0 +1,32 @@
+package crm
+
+import "net/url"
+
+func (c crm) ListPipelines() ([]Pipeline, error) {
+	var pipelines []Pipeline
+	err := c.get(c.url(PipelinesPath, nil), &pipelines)
+	if err != nil {
+		return nil, err
+	}
+
+	return pipelines, nil
+}
+
+// ListDealStages returns the stages of a pipeline, or of the default one when
+// pipelineID is empty.
+func (c crm) ListDealStages(pipelineID string) ([]DealStage, error) {
+	query := url.Values{}
+	if pipelineID != "" {
+		query.Set("deal_pipeline_id", pipelineID)
+	}
+
+	var response struct {
+		DealStages []DealStage `json:"deal_stages"`
+	}
+	err := c.get(c.url(DealStagesPath, query), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.DealStages, nil
+}
@@ -0,0 +1,42 @@
+package crm
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/flan6/rdstation"
+)
+
+func (c crm) ListProducts(ctx context.Context) *rdstation.Iterator[Product] {
+	return pages(ctx, c, ProductsPath, nil, listOf[Product]("products"))
+}
+
+func (c crm) GetProduct(id string) (*Product, error) {
+	var product Product
+	err := c.get(c.url(ProductsPath+"/"+id, nil), &product)
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+func (c crm) CreateProduct(product *Product) (*Product, error) {
+	var created Product
+	err := c.do(http.MethodPost, c.url(ProductsPath, nil), map[string]*Product{"product": product}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c crm) UpdateProduct(product *Product) (*Product, error) {
+	var updated Product
+	err := c.do(http.MethodPut, c.url(ProductsPath+"/"+product.ID, nil), map[string]*Product{"product": product}, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
@@ -0,0 +1,69 @@
+package crm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/flan6/rdstation"
+)
+
+type TaskQuery struct {
+	DealID string
+	UserID string
+	Type   string
+	Done   *bool
+}
+
+func (q TaskQuery) values() url.Values {
+	values := url.Values{}
+	if q.DealID != "" {
+		values.Set("deal_id", q.DealID)
+	}
+	if q.UserID != "" {
+		values.Set("user_id", q.UserID)
+	}
+	if q.Type != "" {
+		values.Set("type", q.Type)
+	}
+	if q.Done != nil {
+		values.Set("done", fmt.Sprint(*q.Done))
+	}
+
+	return values
+}
+
+func (c crm) ListTasks(ctx context.Context, query TaskQuery) *rdstation.Iterator[Task] {
+	return pages(ctx, c, TasksPath, query.values(), listOf[Task]("tasks"))
+}
+
+func (c crm) GetTask(id string) (*Task, error) {
+	var task Task
+	err := c.get(c.url(TasksPath+"/"+id, nil), &task)
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (c crm) CreateTask(task *Task) (*Task, error) {
+	var created Task
+	err := c.do(http.MethodPost, c.url(TasksPath, nil), map[string]*Task{"task": task}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c crm) UpdateTask(task *Task) (*Task, error) {
+	var updated Task
+	err := c.do(http.MethodPut, c.url(TasksPath+"/"+task.ID, nil), map[string]*Task{"task": task}, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
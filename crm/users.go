@@ -0,0 +1,13 @@
+package crm
+
+func (c crm) ListUsers() ([]User, error) {
+	var response struct {
+		Users []User `json:"users"`
+	}
+	err := c.get(c.url(UsersPath, nil), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Users, nil
+}
@@ -0,0 +1,78 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE
+package rdstation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type EmailService interface {
+	ListEmails(ctx context.Context, filter entity.EmailFilter) *Iterator[entity.Email]
+	GetEmail(id int) (*entity.Email, error)
+}
+
+// ListEmails iterates over the account emails. Status and type are filtered
+// by RD Station, the send date range is applied to each page as it arrives.
+func (rd rdStation) ListEmails(ctx context.Context, emailFilter entity.EmailFilter) *Iterator[entity.Email] {
+	query := url.Values{}
+	if emailFilter.Status != "" {
+		query.Set("status", emailFilter.Status)
+	}
+	if emailFilter.Type != "" {
+		query.Set("email_type", emailFilter.Type)
+	}
+
+	it := NewPageIterator(ctx, MaxPageSize, func(ctx context.Context, page, pageSize int) ([]entity.Email, error) {
+		query := cloneValues(query)
+		query.Set("page", fmt.Sprint(page))
+		query.Set("page_size", fmt.Sprint(pageSize))
+
+		var response struct {
+			Items []entity.Email `json:"items"`
+		}
+		err := rd.get(fmt.Sprintf("%s%s?%s", RDURL, RDEmailsPath, query.Encode()), &response)
+
+		return response.Items, err
+	})
+
+	if emailFilter.SentAfter == "" && emailFilter.SentBefore == "" {
+		return it
+	}
+
+	return filter(it, func(email entity.Email) bool {
+		if email.SendAt == "" {
+			return false
+		}
+
+		// send_at is RFC 3339, its date prefix compares as YYYY-MM-DD
+		date := email.SendAt
+		if len(date) > len(entity.AnalyticsDateLayout) {
+			date = date[:len(entity.AnalyticsDateLayout)]
+		}
+
+		return (emailFilter.SentAfter == "" || date >= emailFilter.SentAfter) &&
+			(emailFilter.SentBefore == "" || date <= emailFilter.SentBefore)
+	})
+}
+
+func (rd rdStation) GetEmail(id int) (*entity.Email, error) {
+	var email entity.Email
+	err := rd.get(fmt.Sprintf("%s%s/%d", RDURL, RDEmailsPath, id), &email)
+	if err != nil {
+		return nil, err
+	}
+
+	return &email, nil
+}
+
+func cloneValues(values url.Values) url.Values {
+	cloned := make(url.Values, len(values))
+	for key, value := range values {
+		cloned[key] = append([]string{}, value...)
+	}
+
+	return cloned
+}
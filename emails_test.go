@@ -0,0 +1,84 @@
+package rdstation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_ListEmails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	response := []byte(`{"items": [
+		{"id": 1, "name": "Newsletter junho", "status": "SENT", "send_at": "2022-06-28T10:00:00-03:00"},
+		{"id": 2, "name": "Newsletter julho", "status": "SENT", "send_at": "2022-07-05T10:00:00-03:00"},
+		{"id": 3, "name": "Rascunho", "status": "SENT"}
+	]}`)
+
+	t.Run("status and dates", func(t *testing.T) {
+		url := fmt.Sprintf("%s%s?page=1&page_size=%d&status=SENT", RDURL, RDEmailsPath, MaxPageSize)
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(response, nil)
+
+		got, err := Collect(rd.ListEmails(context.Background(), entity.EmailFilter{
+			Status:    entity.EmailStatusSent,
+			SentAfter: "2022-07-01",
+		}), 0)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, 2, got[0].ID)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		url := fmt.Sprintf("%s%s?page=1&page_size=%d", RDURL, RDEmailsPath, MaxPageSize)
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(response, nil)
+
+		got, err := Collect(rd.ListEmails(context.Background(), entity.EmailFilter{}), 0)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+	})
+}
+
+func TestRdStation_GetEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	url := fmt.Sprintf("%s%s/%d", RDURL, RDEmailsPath, 42)
+
+	t.Run("success", func(t *testing.T) {
+		client.EXPECT().Request(url, http.MethodGet, nil).
+			Return([]byte(`{"id": 42, "name": "Newsletter", "subject": "Novidades", "status": "SENT", "campaign_id": 7}`), nil)
+
+		got, err := rd.GetEmail(42)
+		require.NoError(t, err)
+		require.Equal(t, "Novidades", got.Subject)
+
+		analytics := entity.EmailAnalytics{Emails: []entity.EmailCampaignStats{{CampaignID: 7, OpenedCount: 3}}}
+		require.Equal(t, 3, analytics.StatsFor(got.CampaignID).OpenedCount)
+		require.Nil(t, analytics.StatsFor(8))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(nil, errors.New("batata"))
+
+		got, err := rd.GetEmail(42)
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+}
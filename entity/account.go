@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type AccountInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type accountInfo struct {
+	ID   json.RawMessage `json:"id"`
+	Name string          `json:"name"`
+}
+
+// UnmarshalJSON accepts the id both as a number and as a string.
+func (a *AccountInfo) UnmarshalJSON(data []byte) error {
+	var raw accountInfo
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	a.Name = raw.Name
+	a.ID = strings.Trim(string(raw.ID), `"`)
+	if a.ID == "null" {
+		a.ID = ""
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package entity
+
+import "time"
+
+// AnalyticsDateLayout is the date format of analytics queries and results.
+const AnalyticsDateLayout = "2006-01-02"
+
+type DateRange struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// NewDateRange builds a range from start to end, both inclusive.
+func NewDateRange(start, end time.Time) DateRange {
+	return DateRange{
+		StartDate: start.Format(AnalyticsDateLayout),
+		EndDate:   end.Format(AnalyticsDateLayout),
+	}
+}
+
+type EmailAnalytics struct {
+	AccountID int                  `json:"account_id"`
+	QueryDate DateRange            `json:"query_date"`
+	Emails    []EmailCampaignStats `json:"emails"`
+}
+
+// StatsFor returns the statistics of a campaign, or nil when it was not sent
+// in the queried period.
+func (a *EmailAnalytics) StatsFor(campaignID int) *EmailCampaignStats {
+	for i := range a.Emails {
+		if a.Emails[i].CampaignID == campaignID {
+			return &a.Emails[i]
+		}
+	}
+
+	return nil
+}
+
+type EmailCampaignStats struct {
+	CampaignID        int     `json:"campaign_id"`
+	CampaignName      string  `json:"campaign_name"`
+	SendAt            string  `json:"send_at"`
+	ContactsCount     int     `json:"contacts_count"`
+	DroppedCount      int     `json:"email_dropped_count"`
+	DeliveredCount    int     `json:"email_delivered_count"`
+	BouncedCount      int     `json:"email_bounced_count"`
+	OpenedCount       int     `json:"email_opened_count"`
+	ClickedCount      int     `json:"email_clicked_count"`
+	UnsubscribedCount int     `json:"email_unsubscribed_count"`
+	SpamReportedCount int     `json:"email_spam_reported_count"`
+	DeliveredRate     float64 `json:"email_delivered_rate"`
+	OpenedRate        float64 `json:"email_opened_rate"`
+	ClickedRate       float64 `json:"email_clicked_rate"`
+	SpamReportedRate  float64 `json:"email_spam_reported_rate"`
+}
+
+// Asset types accepted by the conversion analytics filter.
+const (
+	AssetLandingPage = "LandingPage"
+	AssetPopup       = "Popup"
+	AssetForm        = "Form"
+)
+
+type ConversionAnalytics struct {
+	AccountID int               `json:"account_id"`
+	QueryDate DateRange         `json:"query_date"`
+	Assets    []ConversionStats `json:"assets"`
+}
+
+type ConversionStats struct {
+	AssetID          int     `json:"asset_id"`
+	AssetIdentifier  string  `json:"asset_identifier"`
+	AssetType        string  `json:"asset_type"`
+	AssetCreatedAt   string  `json:"asset_created_at"`
+	AssetUpdatedAt   string  `json:"asset_updated_at"`
+	VisitsCount      int     `json:"visits_count"`
+	ConversionsCount int     `json:"conversions_count"`
+	ConversionRate   float64 `json:"conversion_rate"`
+}
+
+// ConversionPoint holds the conversion stats of a single day.
+type ConversionPoint struct {
+	Date   string            `json:"date"`
+	Assets []ConversionStats `json:"assets"`
+}
+
+type FunnelAnalytics struct {
+	AccountID int           `json:"account_id"`
+	QueryDate DateRange     `json:"query_date"`
+	Series    []FunnelPoint `json:"series"`
+}
+
+// FunnelPoint is the funnel of a single day.
+type FunnelPoint struct {
+	Date                   string `json:"date"`
+	VisitorsCount          int    `json:"visitors_count"`
+	ContactsCount          int    `json:"contacts_count"`
+	QualifiedContactsCount int    `json:"qualified_contacts_count"`
+	OpportunitiesCount     int    `json:"opportunities_count"`
+	SalesCount             int    `json:"sales_count"`
+}
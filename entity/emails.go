@@ -0,0 +1,32 @@
+package entity
+
+// Email statuses accepted by EmailFilter.
+const (
+	EmailStatusDraft     = "DRAFT"
+	EmailStatusScheduled = "SCHEDULED"
+	EmailStatusSending   = "SENDING"
+	EmailStatusSent      = "SENT"
+)
+
+type Email struct {
+	ID                  int    `json:"id"`
+	Name                string `json:"name"`
+	Subject             string `json:"subject,omitempty"`
+	Status              string `json:"status"`
+	Type                string `json:"type,omitempty"`
+	CampaignID          int    `json:"campaign_id,omitempty"`
+	SendAt              string `json:"send_at,omitempty"`
+	CreatedAt           string `json:"created_at"`
+	UpdatedAt           string `json:"updated_at"`
+	LeadsCount          int    `json:"leads_count,omitempty"`
+	IsPredictiveSending bool   `json:"is_predictive_sending,omitempty"`
+	SendingIsImminent   bool   `json:"sending_is_imminent,omitempty"`
+}
+
+type EmailFilter struct {
+	Status string
+	Type   string
+	// SentAfter and SentBefore filter by send date, as YYYY-MM-DD.
+	SentAfter  string
+	SentBefore string
+}
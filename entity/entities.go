@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -30,6 +32,71 @@ type Lead struct {
 	Linkedin      string   `json:"linkedin,omitempty"`
 	Tags          []string `json:"tags,omitempty"`
 	ExtraEmails   []string `json:"extra_emails,omitempty"`
+
+	// CustomFields holds the account specific fields, keyed by their api
+	// identifier (cf_*). They are flattened into the lead json.
+	CustomFields map[string]interface{} `json:"-"`
+}
+
+const CustomFieldPrefix = "cf_"
+
+type lead Lead
+
+func (l Lead) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(lead(l))
+	if err != nil {
+		return nil, err
+	}
+
+	return withCustomFields(data, l.CustomFields)
+}
+
+// withCustomFields adds the custom fields to the json object in data.
+func withCustomFields(data []byte, customFields map[string]interface{}) ([]byte, error) {
+	if len(customFields) == 0 {
+		return data, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range customFields {
+		fields[name], err = json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+func (l *Lead) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*lead)(l))
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range fields {
+		if !strings.HasPrefix(name, CustomFieldPrefix) {
+			continue
+		}
+
+		if l.CustomFields == nil {
+			l.CustomFields = map[string]interface{}{}
+		}
+		l.CustomFields[name] = value
+	}
+
+	return nil
 }
 
 func (l *Lead) Empty() bool {
@@ -49,7 +116,8 @@ func (l *Lead) Empty() bool {
 			l.Facebook == "" &&
 			l.Linkedin == "" &&
 			l.Tags == nil &&
-			l.ExtraEmails == nil
+			l.ExtraEmails == nil &&
+			l.CustomFields == nil
 }
 
 func (l *Lead) HasTag(tag string) bool {
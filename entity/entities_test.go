@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -30,3 +31,40 @@ func TestEmpty(t *testing.T) {
 
 	require.False(t, lead.Empty())
 }
+
+func TestLeadCustomFields(t *testing.T) {
+	lead := Lead{
+		Email: "biro@email.com",
+		CustomFields: map[string]interface{}{
+			"cf_plano": "anual",
+			"cf_vidas": float64(3),
+		},
+	}
+
+	data, err := json.Marshal(lead)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"email":"biro@email.com","cf_plano":"anual","cf_vidas":3}`, string(data))
+
+	var decoded Lead
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, lead, decoded)
+
+	data, err = json.Marshal(Lead{Email: "biro@email.com"})
+	require.NoError(t, err)
+	require.Equal(t, `{"email":"biro@email.com"}`, string(data))
+
+	require.False(t, (&Lead{CustomFields: map[string]interface{}{}}).Empty())
+}
+
+func TestAccountInfo_UnmarshalJSON(t *testing.T) {
+	var info AccountInfo
+
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 42, "name": "Qual"}`), &info))
+	require.Equal(t, AccountInfo{ID: "42", Name: "Qual"}, info)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "abc", "name": "Qual"}`), &info))
+	require.Equal(t, AccountInfo{ID: "abc", Name: "Qual"}, info)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Qual"}`), &info))
+	require.Equal(t, AccountInfo{Name: "Qual"}, info)
+}
@@ -0,0 +1,137 @@
+package entity
+
+import "encoding/json"
+
+const (
+	EventFamilyCDP = "CDP"
+
+	// EVENT TYPES
+	EventTypeConversion        = "CONVERSION"
+	EventTypeOrderPlaced       = "ORDER_PLACED"
+	EventTypeOrderPlacedItem   = "ORDER_PLACED_ITEM"
+	EventTypeCartAbandoned     = "CART_ABANDONED"
+	EventTypeCartAbandonedItem = "CART_ABANDONED_ITEM"
+)
+
+type Event struct {
+	EventType   string      `json:"event_type"`
+	EventFamily string      `json:"event_family"`
+	Payload     interface{} `json:"payload"`
+}
+
+type LegalBase struct {
+	Category string `json:"category"`
+	Type     string `json:"type"`
+	Status   string `json:"status,omitempty"`
+}
+
+type Order struct {
+	Name          string      `json:"name,omitempty"`
+	Email         string      `json:"email"`
+	OrderID       string      `json:"cf_order_id"`
+	TotalItems    int         `json:"cf_order_total_items,omitempty"`
+	Status        string      `json:"cf_order_status,omitempty"`
+	PaymentMethod string      `json:"cf_order_payment_method,omitempty"`
+	Amount        float64     `json:"cf_order_payment_amount,omitempty"`
+	Currency      string      `json:"cf_order_currency,omitempty"`
+	URL           string      `json:"cf_order_url,omitempty"`
+	LegalBases    []LegalBase `json:"legal_bases,omitempty"`
+}
+
+type OrderItem struct {
+	Name        string      `json:"name,omitempty"`
+	Email       string      `json:"email"`
+	OrderID     string      `json:"cf_order_id"`
+	ProductID   string      `json:"cf_order_product_id"`
+	ProductSKU  string      `json:"cf_order_product_sku,omitempty"`
+	ProductName string      `json:"cf_order_product_name,omitempty"`
+	Price       float64     `json:"cf_order_product_price,omitempty"`
+	Quantity    int         `json:"cf_order_product_quantity,omitempty"`
+	Currency    string      `json:"cf_order_currency,omitempty"`
+	ProductURL  string      `json:"cf_order_product_url,omitempty"`
+	LegalBases  []LegalBase `json:"legal_bases,omitempty"`
+}
+
+type Cart struct {
+	Name       string      `json:"name,omitempty"`
+	Email      string      `json:"email"`
+	CartID     string      `json:"cf_cart_id"`
+	TotalItems int         `json:"cf_cart_total_items,omitempty"`
+	Status     string      `json:"cf_cart_status,omitempty"`
+	Amount     float64     `json:"cf_cart_amount,omitempty"`
+	Currency   string      `json:"cf_cart_currency,omitempty"`
+	URL        string      `json:"cf_cart_url,omitempty"`
+	LegalBases []LegalBase `json:"legal_bases,omitempty"`
+}
+
+type CartItem struct {
+	Name        string      `json:"name,omitempty"`
+	Email       string      `json:"email"`
+	CartID      string      `json:"cf_cart_id"`
+	ProductID   string      `json:"cf_cart_product_id"`
+	ProductSKU  string      `json:"cf_cart_product_sku,omitempty"`
+	ProductName string      `json:"cf_cart_product_name,omitempty"`
+	Price       float64     `json:"cf_cart_product_price,omitempty"`
+	Quantity    int         `json:"cf_cart_product_quantity,omitempty"`
+	Currency    string      `json:"cf_cart_currency,omitempty"`
+	ProductURL  string      `json:"cf_cart_product_url,omitempty"`
+	LegalBases  []LegalBase `json:"legal_bases,omitempty"`
+}
+
+type Conversion struct {
+	ConversionIdentifier string      `json:"conversion_identifier"`
+	Email                string      `json:"email"`
+	Name                 string      `json:"name,omitempty"`
+	JobTitle             string      `json:"job_title,omitempty"`
+	City                 string      `json:"city,omitempty"`
+	State                string      `json:"state,omitempty"`
+	Country              string      `json:"country,omitempty"`
+	PersonalPhone        string      `json:"personal_phone,omitempty"`
+	MobilePhone          string      `json:"mobile_phone,omitempty"`
+	Twitter              string      `json:"twitter,omitempty"`
+	Facebook             string      `json:"facebook,omitempty"`
+	Linkedin             string      `json:"linkedin,omitempty"`
+	Website              string      `json:"website,omitempty"`
+	CompanyName          string      `json:"company_name,omitempty"`
+	TrafficSource        string      `json:"traffic_source,omitempty"`
+	TrafficMedium        string      `json:"traffic_medium,omitempty"`
+	TrafficCampaign      string      `json:"traffic_campaign,omitempty"`
+	TrafficValue         string      `json:"traffic_value,omitempty"`
+	Tags                 []string    `json:"tags,omitempty"`
+	LegalBases           []LegalBase `json:"legal_bases,omitempty"`
+
+	CustomFields map[string]interface{} `json:"-"`
+}
+
+// NewConversion fills a conversion of identifier with the lead fields the
+// conversion payload accepts.
+func NewConversion(identifier string, lead *Lead) *Conversion {
+	return &Conversion{
+		ConversionIdentifier: identifier,
+		Email:                lead.Email,
+		Name:                 lead.Name,
+		JobTitle:             lead.JobTitle,
+		City:                 lead.City,
+		State:                lead.State,
+		Country:              lead.Country,
+		PersonalPhone:        lead.PersonalPhone,
+		MobilePhone:          lead.MobilePhone,
+		Twitter:              lead.Twitter,
+		Facebook:             lead.Facebook,
+		Linkedin:             lead.Linkedin,
+		Website:              lead.Website,
+		Tags:                 lead.Tags,
+		CustomFields:         lead.CustomFields,
+	}
+}
+
+type conversion Conversion
+
+func (c Conversion) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(conversion(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return withCustomFields(data, c.CustomFields)
+}
@@ -0,0 +1,20 @@
+package entity
+
+// Lifecycle stages of the marketing funnel.
+const (
+	LifecycleLead          = "Lead"
+	LifecycleQualifiedLead = "Qualified Lead"
+	LifecycleClient        = "Client"
+)
+
+// DefaultFunnel is the only funnel the api exposes for now.
+const DefaultFunnel = "default"
+
+type Funnel struct {
+	LifecycleStage    string `json:"lifecycle_stage,omitempty"`
+	Opportunity       bool   `json:"opportunity"`
+	ContactOwnerEmail string `json:"contact_owner_email,omitempty"`
+	Interest          int    `json:"interest,omitempty"`
+	Fit               int    `json:"fit,omitempty"`
+	OriginSource      string `json:"origin,omitempty"`
+}
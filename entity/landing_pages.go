@@ -0,0 +1,30 @@
+package entity
+
+const (
+	AssetStatusPublished   = "PUBLISHED"
+	AssetStatusUnpublished = "UNPUBLISHED"
+	AssetStatusDraft       = "DRAFT"
+)
+
+type LandingPage struct {
+	ID                   int    `json:"id"`
+	Title                string `json:"title"`
+	URL                  string `json:"url,omitempty"`
+	ConversionIdentifier string `json:"conversion_identifier"`
+	Status               string `json:"status"`
+	HasActiveExperiment  bool   `json:"has_active_experiment"`
+	HadExperiment        bool   `json:"had_experiment"`
+	CreatedAt            string `json:"created_at"`
+	UpdatedAt            string `json:"updated_at"`
+}
+
+type Popup struct {
+	ID                   int    `json:"id"`
+	Title                string `json:"title"`
+	URL                  string `json:"url,omitempty"`
+	ConversionIdentifier string `json:"conversion_identifier"`
+	Status               string `json:"status"`
+	Trigger              string `json:"trigger,omitempty"`
+	CreatedAt            string `json:"created_at"`
+	UpdatedAt            string `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+type Link struct {
+	Rel   string `json:"rel"`
+	Href  string `json:"href"`
+	Media string `json:"media,omitempty"`
+	Type  string `json:"type,omitempty"`
+}
+
+type Segmentation struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Standard      bool   `json:"standard"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	ProcessStatus string `json:"process_status"`
+	Links         []Link `json:"links,omitempty"`
+}
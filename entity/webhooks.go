@@ -0,0 +1,18 @@
+package entity
+
+const (
+	WebhookEntityContact = "CONTACT"
+
+	WebhookEventConverted         = "WEBHOOK.CONVERTED"
+	WebhookEventMarkedOpportunity = "WEBHOOK.MARKED_OPPORTUNITY"
+)
+
+type WebhookSubscription struct {
+	UUID             string   `json:"uuid,omitempty"`
+	EntityType       string   `json:"entity_type"`
+	EventType        string   `json:"event_type"`
+	EventIdentifiers []string `json:"event_identifiers,omitempty"`
+	URL              string   `json:"url"`
+	HTTPMethod       string   `json:"http_method"`
+	IncludeRelations []string `json:"include_relations,omitempty"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+const (
+	WorkflowStatusActive = "ACTIVE"
+	WorkflowStatusPaused = "PAUSED"
+	WorkflowStatusDraft  = "DRAFT"
+
+	WorkflowEntryConversion   = "CONVERSION"
+	WorkflowEntrySegmentation = "SEGMENTATION"
+)
+
+type Workflow struct {
+	ID            string                   `json:"id"`
+	Name          string                   `json:"name"`
+	Status        string                   `json:"status"`
+	EntryCriteria []WorkflowEntryCriterion `json:"entry_criteria,omitempty"`
+	CreatedAt     string                   `json:"created_at"`
+	UpdatedAt     string                   `json:"updated_at"`
+}
+
+type WorkflowEntryCriterion struct {
+	Type                  string   `json:"type"`
+	ConversionIdentifiers []string `json:"conversion_identifiers,omitempty"`
+	SegmentationID        int      `json:"segmentation_id,omitempty"`
+}
+
+// ConversionIdentifiers lists the conversions that make a contact enter the
+// workflow.
+func (w *Workflow) ConversionIdentifiers() []string {
+	var identifiers []string
+	for _, criterion := range w.EntryCriteria {
+		if criterion.Type == WorkflowEntryConversion {
+			identifiers = append(identifiers, criterion.ConversionIdentifiers...)
+		}
+	}
+
+	return identifiers
+}
@@ -0,0 +1,151 @@
+package rdstation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+const (
+	// MaxEventsBatchSize is the largest number of events accepted by a single
+	// call to the batch endpoint.
+	MaxEventsBatchSize = 25
+	// EventsBatchConcurrency bounds how many batch requests are in flight.
+	EventsBatchConcurrency = 4
+)
+
+// EventResult reports the outcome of a single event sent through
+// SendEventsBatch. Index is the position of the event in the input slice and
+// UUID the id RD Station gave to the event. When a request fails as a whole,
+// or its response has not one entry per event, every event of the chunk gets
+// the same Err and no UUID.
+type EventResult struct {
+	Index int
+	UUID  string
+	Err   error
+}
+
+type batchEntry struct {
+	EventUUID string  `json:"event_uuid"`
+	Errors    *Errors `json:"errors"`
+}
+
+func (rd rdStation) SendEvent(event *entity.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = rd.client.Request(fmt.Sprintf("%s%s", RDURL, RDEventsPath), http.MethodPost, data)
+
+	return err
+}
+
+func (rd rdStation) SendConversion(conversion *entity.Conversion) error {
+	return rd.SendEvent(&entity.Event{
+		EventType:   entity.EventTypeConversion,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     conversion,
+	})
+}
+
+func (rd rdStation) SendOrderPlaced(order *entity.Order) error {
+	return rd.SendEvent(&entity.Event{
+		EventType:   entity.EventTypeOrderPlaced,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     order,
+	})
+}
+
+func (rd rdStation) SendOrderPlacedItem(item *entity.OrderItem) error {
+	return rd.SendEvent(&entity.Event{
+		EventType:   entity.EventTypeOrderPlacedItem,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     item,
+	})
+}
+
+func (rd rdStation) SendCartAbandoned(cart *entity.Cart) error {
+	return rd.SendEvent(&entity.Event{
+		EventType:   entity.EventTypeCartAbandoned,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     cart,
+	})
+}
+
+func (rd rdStation) SendCartAbandonedItem(item *entity.CartItem) error {
+	return rd.SendEvent(&entity.Event{
+		EventType:   entity.EventTypeCartAbandonedItem,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     item,
+	})
+}
+
+// SendEventsBatch splits events in chunks of MaxEventsBatchSize and sends them
+// concurrently. The returned slice has one result per event, in input order.
+func (rd rdStation) SendEventsBatch(events []entity.Event) []EventResult {
+	results := make([]EventResult, len(events))
+	for i := range results {
+		results[i].Index = i
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, EventsBatchConcurrency)
+	)
+	for start := 0; start < len(events); start += MaxEventsBatchSize {
+		end := start + MaxEventsBatchSize
+		if end > len(events) {
+			end = len(events)
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			entries, err := rd.sendBatch(events[start:end])
+			for i := start; i < end; i++ {
+				results[i].Err = err
+			}
+			if err != nil || len(entries) != end-start {
+				return
+			}
+
+			for i, entry := range entries {
+				results[start+i].UUID = entry.EventUUID
+				if entry.Errors != nil {
+					// Errors of a single event are validation failures.
+					entry.Errors.StatusCode = http.StatusUnprocessableEntity
+					results[start+i].Err = RDError{Errors: *entry.Errors}
+				}
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (rd rdStation) sendBatch(events []entity.Event) ([]batchEntry, error) {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := rd.client.Request(fmt.Sprintf("%s%s", RDURL, RDBatchPath), http.MethodPost, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// The events were accepted, a response that is not a list of results
+	// only leaves them without uuid.
+	var entries []batchEntry
+	_ = json.Unmarshal(result, &entries)
+
+	return entries, nil
+}
@@ -0,0 +1,151 @@
+package rdstation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_SendEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	url := fmt.Sprintf("%s%s", RDURL, RDEventsPath)
+
+	t.Run("order placed", func(t *testing.T) {
+		order := entity.Order{Email: "email", OrderID: "42", Amount: 99.9, Currency: "BRL"}
+		data, err := json.Marshal(entity.Event{
+			EventType:   entity.EventTypeOrderPlaced,
+			EventFamily: entity.EventFamilyCDP,
+			Payload:     &order,
+		})
+		require.NoError(t, err)
+
+		client.EXPECT().Request(url, http.MethodPost, data).Return(nil, nil)
+
+		err = rd.SendOrderPlaced(&order)
+		require.NoError(t, err)
+	})
+
+	t.Run("order placed item", func(t *testing.T) {
+		item := entity.OrderItem{Email: "email", OrderID: "42", ProductID: "p1"}
+		data, err := json.Marshal(entity.Event{
+			EventType:   entity.EventTypeOrderPlacedItem,
+			EventFamily: entity.EventFamilyCDP,
+			Payload:     &item,
+		})
+		require.NoError(t, err)
+
+		client.EXPECT().Request(url, http.MethodPost, data).Return(nil, nil)
+
+		err = rd.SendOrderPlacedItem(&item)
+		require.NoError(t, err)
+	})
+
+	t.Run("cart abandoned", func(t *testing.T) {
+		cart := entity.Cart{Email: "email", CartID: "c1", URL: "https://loja.com/cart/c1"}
+		data, err := json.Marshal(entity.Event{
+			EventType:   entity.EventTypeCartAbandoned,
+			EventFamily: entity.EventFamilyCDP,
+			Payload:     &cart,
+		})
+		require.NoError(t, err)
+
+		client.EXPECT().Request(url, http.MethodPost, data).Return(nil, nil)
+
+		err = rd.SendCartAbandoned(&cart)
+		require.NoError(t, err)
+	})
+
+	t.Run("cart abandoned item", func(t *testing.T) {
+		item := entity.CartItem{Email: "email", CartID: "c1", ProductID: "p1"}
+		data, err := json.Marshal(entity.Event{
+			EventType:   entity.EventTypeCartAbandonedItem,
+			EventFamily: entity.EventFamilyCDP,
+			Payload:     &item,
+		})
+		require.NoError(t, err)
+
+		client.EXPECT().Request(url, http.MethodPost, data).Return(nil, errors.New("err"))
+
+		err = rd.SendCartAbandonedItem(&item)
+		require.Error(t, err)
+	})
+}
+
+func TestRdStation_SendEventsBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	url := fmt.Sprintf("%s%s", RDURL, RDBatchPath)
+
+	events := make([]entity.Event, MaxEventsBatchSize*2+3)
+	for i := range events {
+		events[i] = entity.Event{
+			EventType:   entity.EventTypeCartAbandoned,
+			EventFamily: entity.EventFamilyCDP,
+			Payload:     entity.Cart{Email: fmt.Sprintf("%d@email.com", i), CartID: fmt.Sprint(i)},
+		}
+	}
+
+	first, err := json.Marshal(events[:MaxEventsBatchSize])
+	require.NoError(t, err)
+	second, err := json.Marshal(events[MaxEventsBatchSize : MaxEventsBatchSize*2])
+	require.NoError(t, err)
+	last, err := json.Marshal(events[MaxEventsBatchSize*2:])
+	require.NoError(t, err)
+
+	entries := make([]map[string]interface{}, MaxEventsBatchSize)
+	for i := range entries {
+		entries[i] = map[string]interface{}{"event_uuid": fmt.Sprint("uuid-", i)}
+	}
+	entries[3] = map[string]interface{}{"errors": map[string]string{"error_type": "INVALID_FIELDS", "error_message": "batata"}}
+	firstResult, err := json.Marshal(entries)
+	require.NoError(t, err)
+
+	target := errors.New("batata")
+	client.EXPECT().Request(url, http.MethodPost, first).Return(firstResult, nil)
+	client.EXPECT().Request(url, http.MethodPost, second).Return(nil, target)
+	client.EXPECT().Request(url, http.MethodPost, last).Return(nil, nil)
+
+	results := rd.SendEventsBatch(events)
+	require.Len(t, results, len(events))
+
+	for i, result := range results {
+		require.Equal(t, i, result.Index)
+		switch {
+		case i == 3:
+			var rdErr RDError
+			require.ErrorAs(t, result.Err, &rdErr)
+			require.Equal(t, "INVALID_FIELDS", rdErr.Errors.Type)
+			require.Empty(t, result.UUID)
+		case i < MaxEventsBatchSize:
+			require.NoError(t, result.Err)
+			require.Equal(t, fmt.Sprint("uuid-", i), result.UUID)
+		case i < MaxEventsBatchSize*2:
+			require.Equal(t, target, result.Err)
+			require.Empty(t, result.UUID)
+		default:
+			require.NoError(t, result.Err)
+			require.Empty(t, result.UUID)
+		}
+	}
+
+	require.Empty(t, rd.SendEventsBatch(nil))
+}
@@ -0,0 +1,50 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE
+package rdstation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type FunnelService interface {
+	GetFunnel(email string) (*entity.Funnel, error)
+	UpdateFunnel(email string, funnel *entity.Funnel) (*entity.Funnel, error)
+}
+
+func funnelURL(email string) string {
+	return fmt.Sprintf("%s%semail:%s/funnels/%s", RDURL, RDLeadPath, email, entity.DefaultFunnel)
+}
+
+func (rd rdStation) GetFunnel(email string) (*entity.Funnel, error) {
+	var funnel entity.Funnel
+	err := rd.get(funnelURL(email), &funnel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &funnel, nil
+}
+
+// UpdateFunnel sets the lead stage and opportunity in the default funnel.
+func (rd rdStation) UpdateFunnel(email string, funnel *entity.Funnel) (*entity.Funnel, error) {
+	data, err := json.Marshal(funnel)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = rd.client.Request(funnelURL(email), http.MethodPut, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated entity.Funnel
+	err = json.Unmarshal(data, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
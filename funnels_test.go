@@ -0,0 +1,41 @@
+package rdstation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_UpdateFunnel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	url := fmt.Sprintf("%s%semail:%s/funnels/default", RDURL, RDLeadPath, "batata@example.com")
+
+	client.EXPECT().Request(url, http.MethodPut, []byte(`{"lifecycle_stage":"Client","opportunity":true}`)).
+		Return([]byte(`{"lifecycle_stage":"Client","opportunity":true,"contact_owner_email":null,"interest":20}`), nil)
+
+	funnel, err := rd.UpdateFunnel("batata@example.com", &entity.Funnel{
+		LifecycleStage: entity.LifecycleClient,
+		Opportunity:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &entity.Funnel{LifecycleStage: "Client", Opportunity: true, Interest: 20}, funnel)
+
+	t.Run("get", func(t *testing.T) {
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(nil, errors.New("batata"))
+
+		funnel, err := rd.GetFunnel("batata@example.com")
+		require.Error(t, err)
+		require.Nil(t, funnel)
+	})
+}
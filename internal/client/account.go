@@ -0,0 +1,86 @@
+package client
+
+import (
+	"errors"
+	"sync"
+)
+
+// AccountClient attaches the account id, once known, to the errors of the
+// wrapped Client.
+type AccountClient struct {
+	Client
+
+	mu        sync.RWMutex
+	accountID string
+
+	lookup   func(Client) (string, error)
+	lookupMu sync.Mutex
+}
+
+func WithAccount(c Client) *AccountClient {
+	return &AccountClient{Client: c}
+}
+
+// WithAccountLookup is WithAccount, but the first failed request whose
+// account is still unknown resolves it with lookup, sent through c. A failed
+// lookup is retried on the next failed request.
+func WithAccountLookup(c Client, lookup func(Client) (string, error)) *AccountClient {
+	return &AccountClient{Client: c, lookup: lookup}
+}
+
+func (c *AccountClient) SetAccountID(accountID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.accountID = accountID
+}
+
+func (c *AccountClient) AccountID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.accountID
+}
+
+func (c *AccountClient) Request(path, method string, data []byte) ([]byte, error) {
+	result, err := c.Client.Request(path, method, data)
+	if err == nil {
+		return result, nil
+	}
+
+	accountID := c.resolve()
+	if accountID == "" {
+		return nil, err
+	}
+
+	var rdErr RDError
+	if errors.As(err, &rdErr) {
+		rdErr.Errors.AccountID = accountID
+		return nil, rdErr
+	}
+
+	return nil, AccountError{AccountID: accountID, Err: err}
+}
+
+func (c *AccountClient) resolve() string {
+	if accountID := c.AccountID(); accountID != "" || c.lookup == nil {
+		return accountID
+	}
+
+	// Concurrent failures wait for the same lookup instead of sending their
+	// own, and find the account id set when it succeeds.
+	c.lookupMu.Lock()
+	defer c.lookupMu.Unlock()
+
+	if accountID := c.AccountID(); accountID != "" {
+		return accountID
+	}
+
+	accountID, err := c.lookup(c.Client)
+	if err != nil || accountID == "" {
+		return ""
+	}
+	c.SetAccountID(accountID)
+
+	return accountID
+}
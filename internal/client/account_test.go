@@ -0,0 +1,86 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type errorClient struct {
+	err error
+}
+
+func (c errorClient) Request(path, method string, data []byte) ([]byte, error) {
+	return nil, c.err
+}
+
+func TestAccountClient(t *testing.T) {
+	notFound := RDError{Errors: Errors{StatusCode: http.StatusNotFound, Type: "RESOURCE_NOT_FOUND"}}
+
+	cl := WithAccount(errorClient{err: notFound})
+
+	_, err := cl.Request("path", http.MethodGet, nil)
+	require.Equal(t, notFound, err)
+
+	cl.SetAccountID("3127612")
+
+	_, err = cl.Request("path", http.MethodGet, nil)
+	var rdErr RDError
+	require.True(t, errors.As(err, &rdErr))
+	require.Equal(t, "3127612", rdErr.Errors.AccountID)
+	require.Equal(t, "account 3127612: 404: RESOURCE_NOT_FOUND - ", err.Error())
+
+	target := errors.New("connection reset")
+	cl = WithAccount(errorClient{err: target})
+	cl.SetAccountID("3127612")
+
+	_, err = cl.Request("path", http.MethodGet, nil)
+	require.ErrorIs(t, err, target)
+	require.Equal(t, "account 3127612: connection reset", err.Error())
+
+	success := WithAccount(&countClient{})
+	result, err := success.Request("path", http.MethodGet, nil)
+	require.NoError(t, err)
+	require.Equal(t, "path", string(result))
+}
+
+func TestAccountClient_Lookup(t *testing.T) {
+	notFound := RDError{Errors: Errors{StatusCode: http.StatusNotFound}}
+
+	lookups := 0
+	cl := WithAccountLookup(errorClient{err: notFound}, func(c Client) (string, error) {
+		lookups++
+		require.Equal(t, errorClient{err: notFound}, c)
+		return "3127612", nil
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := cl.Request("path", http.MethodGet, nil)
+		var rdErr RDError
+		require.True(t, errors.As(err, &rdErr))
+		require.Equal(t, "3127612", rdErr.Errors.AccountID)
+	}
+	require.Equal(t, 1, lookups)
+
+	lookups = 0
+	failed := WithAccountLookup(errorClient{err: notFound}, func(c Client) (string, error) {
+		lookups++
+		if lookups == 1 {
+			return "", errors.New("batata")
+		}
+		return "3127612", nil
+	})
+
+	_, err := failed.Request("path", http.MethodGet, nil)
+	require.Equal(t, notFound, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = failed.Request("path", http.MethodGet, nil)
+		var rdErr RDError
+		require.True(t, errors.As(err, &rdErr))
+		require.Equal(t, "3127612", rdErr.Errors.AccountID)
+	}
+	require.Equal(t, 2, lookups)
+}
@@ -23,12 +23,18 @@ type Client interface {
 }
 
 func NewClient(secret entity.Secret, endpoint oauth2.Endpoint) (Client, error) {
+	return NewClientWithHTTPClient(secret, endpoint, http.DefaultClient)
+}
+
+// NewClientWithHTTPClient exchanges the refresh token and sends every request,
+// including token refreshes, through httpClient.
+func NewClientWithHTTPClient(secret entity.Secret, endpoint oauth2.Endpoint, httpClient *http.Client) (Client, error) {
 	data, err := json.Marshal(secret)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.Post("https://api.rd.services/auth/token", "application/json", bytes.NewBuffer(data))
+	resp, err := httpClient.Post("https://api.rd.services/auth/token", "application/json", bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -51,10 +57,10 @@ func NewClient(secret entity.Secret, endpoint oauth2.Endpoint) (Client, error) {
 		Endpoint:     endpoint,
 	}
 
-	httpClient := config.Client(context.Background(), token.Auth2Token())
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
 
 	return client{
-		httpClient: httpClient,
+		httpClient: config.Client(ctx, token.Auth2Token()),
 		secret:     secret,
 	}, nil
 }
@@ -75,6 +81,10 @@ func (c client) Request(path, method string, data []byte) ([]byte, error) {
 
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
 	var e RDError
 	if response.StatusCode != http.StatusOK {
 		err := json.NewDecoder(response.Body).Decode(&e)
@@ -83,6 +93,7 @@ func (c client) Request(path, method string, data []byte) ([]byte, error) {
 		}
 
 		e.Errors.StatusCode = response.StatusCode
+		e.Errors.RetryAfter = retryAfter(response.Header)
 
 		return nil, e
 	}
@@ -197,3 +197,16 @@ func TestClient_Request(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestClient_RequestNoContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cl := client{httpClient: server.Client()}
+
+	result, err := cl.Request(server.URL, http.MethodDelete, nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
@@ -1,6 +1,13 @@
 package client
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
 
 type RDError struct {
 	Errors Errors `json:"errors"`
@@ -10,12 +17,65 @@ type Errors struct {
 	StatusCode int
 	Type       string `json:"error_type"`
 	Message    string `json:"error_message"`
+	AccountID  string `json:"-"`
+	// RetryAfter is how long the api asked to wait, on 429 and 503.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e Errors) Error() string {
+	if e.AccountID != "" {
+		return fmt.Sprintf("account %s: %v: %s - %s", e.AccountID, e.StatusCode, e.Type, e.Message)
+	}
+
 	return fmt.Sprintf("%v: %s - %s", e.StatusCode, e.Type, e.Message)
 }
 
 func (e RDError) Error() string {
 	return fmt.Sprintf("%s", e.Errors)
 }
+
+// AccountError tags an error that did not come from the api, such as a
+// network failure, with the account of the client that got it.
+type AccountError struct {
+	AccountID string
+	Err       error
+}
+
+func (e AccountError) Error() string {
+	return fmt.Sprintf("account %s: %v", e.AccountID, e.Err)
+}
+
+func (e AccountError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetriable reports whether err is worth retrying: rate limiting, server
+// side failures and network errors.
+func IsRetriable(err error) bool {
+	var rdErr RDError
+	if errors.As(err, &rdErr) {
+		return rdErr.Errors.StatusCode == http.StatusTooManyRequests ||
+			rdErr.Errors.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryAfter parses the Retry-After header, given in seconds or as a date.
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
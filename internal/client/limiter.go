@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter spaces requests evenly so that no more than a fixed number of them
+// start in a given period.
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func NewLimiter(requests int, per time.Duration) *Limiter {
+	if requests <= 0 {
+		requests = 1
+	}
+
+	return &Limiter{interval: per / time.Duration(requests)}
+}
+
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type limitedClient struct {
+	Client
+	limiter *Limiter
+}
+
+// WithLimiter returns a Client that waits on the limiter before each request.
+func WithLimiter(c Client, limiter *Limiter) Client {
+	return limitedClient{Client: c, limiter: limiter}
+}
+
+func (c limitedClient) Request(path, method string, data []byte) ([]byte, error) {
+	err := c.limiter.Wait(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.Request(path, method, data)
+}
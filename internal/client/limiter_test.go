@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countClient struct {
+	calls int
+}
+
+func (c *countClient) Request(path, method string, data []byte) ([]byte, error) {
+	c.calls++
+	return []byte(path), nil
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	limiter := NewLimiter(10, 100*time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	t.Run("cancelled", func(t *testing.T) {
+		limiter := NewLimiter(1, time.Hour)
+		require.NoError(t, limiter.Wait(context.Background()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+	})
+}
+
+func TestWithLimiter(t *testing.T) {
+	inner := &countClient{}
+	cl := WithLimiter(inner, NewLimiter(100, time.Second))
+
+	result, err := cl.Request("path", http.MethodGet, nil)
+	require.NoError(t, err)
+	require.Equal(t, "path", string(result))
+	require.Equal(t, 1, inner.calls)
+}
+
+func TestIsRetriable(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"rate limited": {err: RDError{Errors: Errors{StatusCode: http.StatusTooManyRequests}}, want: true},
+		"server error": {err: RDError{Errors: Errors{StatusCode: http.StatusBadGateway}}, want: true},
+		"not found":    {err: RDError{Errors: Errors{StatusCode: http.StatusNotFound}}, want: false},
+		"other":        {err: errors.New("batata"), want: false},
+	}
+
+	for name, test := range tests {
+		require.Equal(t, test.want, IsRetriable(test.err), name)
+	}
+}
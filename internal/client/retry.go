@@ -0,0 +1,43 @@
+package client
+
+import (
+	"errors"
+	"time"
+)
+
+type retryClient struct {
+	Client
+	attempts int
+	backoff  time.Duration
+}
+
+// WithRetry returns a Client that sends a request up to attempts times while
+// it fails with a retriable error, waiting backoff, then twice as long after
+// each new failure. A Retry-After sent by the api takes precedence when
+// longer.
+func WithRetry(c Client, attempts int, backoff time.Duration) Client {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return retryClient{Client: c, attempts: attempts, backoff: backoff}
+}
+
+func (c retryClient) Request(path, method string, data []byte) ([]byte, error) {
+	wait := c.backoff
+	for attempt := 1; ; attempt++ {
+		result, err := c.Client.Request(path, method, data)
+		if err == nil || attempt == c.attempts || !IsRetriable(err) {
+			return result, err
+		}
+
+		delay := wait
+		var rdErr RDError
+		if errors.As(err, &rdErr) && rdErr.Errors.RetryAfter > delay {
+			delay = rdErr.Errors.RetryAfter
+		}
+
+		time.Sleep(delay)
+		wait *= 2
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type flakyClient struct {
+	errs  []error
+	calls int
+}
+
+func (c *flakyClient) Request(path, method string, data []byte) ([]byte, error) {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return nil, err
+	}
+
+	return []byte("ok"), nil
+}
+
+func TestWithRetry(t *testing.T) {
+	limited := RDError{Errors: Errors{StatusCode: http.StatusTooManyRequests, RetryAfter: 20 * time.Millisecond}}
+	unavailable := RDError{Errors: Errors{StatusCode: http.StatusServiceUnavailable}}
+
+	t.Run("recovers", func(t *testing.T) {
+		inner := &flakyClient{errs: []error{limited, unavailable}}
+
+		start := time.Now()
+		result, err := WithRetry(inner, 3, time.Millisecond).Request("path", http.MethodGet, nil)
+		require.NoError(t, err)
+		require.Equal(t, "ok", string(result))
+		require.Equal(t, 3, inner.calls)
+		require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		inner := &flakyClient{errs: []error{unavailable, unavailable, unavailable}}
+
+		_, err := WithRetry(inner, 2, time.Millisecond).Request("path", http.MethodGet, nil)
+		require.Equal(t, unavailable, err)
+		require.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("permanent error", func(t *testing.T) {
+		target := errors.New("batata")
+		inner := &flakyClient{errs: []error{target}}
+
+		_, err := WithRetry(inner, 3, time.Millisecond).Request("path", http.MethodGet, nil)
+		require.Equal(t, target, err)
+		require.Equal(t, 1, inner.calls)
+	})
+}
+
+func TestTokenClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "shhhhh", r.URL.Query().Get("token"))
+
+		switch r.URL.Path {
+		case "/deals":
+			require.Equal(t, "2", r.URL.Query().Get("page"))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"deals":[]}`))
+		case "/limited":
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`Too many requests`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":{"error_type":"RESOURCE_NOT_FOUND","error_message":"not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	cl := NewTokenClient(server.Client(), "shhhhh")
+
+	result, err := cl.Request(server.URL+"/deals?page=2", http.MethodGet, nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"deals":[]}`, string(result))
+
+	_, err = cl.Request(server.URL+"/limited", http.MethodGet, nil)
+	var rdErr RDError
+	require.True(t, errors.As(err, &rdErr))
+	require.Equal(t, http.StatusTooManyRequests, rdErr.Errors.StatusCode)
+	require.Equal(t, "TOO_MANY_REQUESTS", rdErr.Errors.Type)
+	require.Equal(t, "Too many requests", rdErr.Errors.Message)
+	require.Equal(t, 3*time.Second, rdErr.Errors.RetryAfter)
+
+	_, err = cl.Request(server.URL+"/missing", http.MethodGet, nil)
+	require.True(t, errors.As(err, &rdErr))
+	require.Equal(t, "RESOURCE_NOT_FOUND", rdErr.Errors.Type)
+
+	server.Close()
+	_, err = cl.Request(server.URL+"/deals", http.MethodGet, nil)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "shhhhh")
+}
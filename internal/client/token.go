@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type tokenClient struct {
+	httpClient *http.Client
+	token      string
+}
+
+// NewTokenClient returns a Client for apis authenticated by a token query
+// parameter, such as RD Station CRM. Error bodies that do not follow the
+// marketing api format are kept whole in the error message.
+func NewTokenClient(httpClient *http.Client, token string) Client {
+	return tokenClient{httpClient: httpClient, token: token}
+}
+
+func (c tokenClient) Request(path, method string, data []byte) ([]byte, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	query.Set("token", c.token)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		// keep the token out of error messages
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = path
+		}
+
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		return body, nil
+	}
+
+	var e RDError
+	if json.Unmarshal(body, &e) != nil || e.Errors.Type == "" && e.Errors.Message == "" {
+		e.Errors = Errors{
+			Type:    strings.ToUpper(strings.ReplaceAll(http.StatusText(response.StatusCode), " ", "_")),
+			Message: strings.TrimSpace(string(body)),
+		}
+	}
+
+	e.Errors.StatusCode = response.StatusCode
+	e.Errors.RetryAfter = retryAfter(response.Header)
+
+	return nil, e
+}
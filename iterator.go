@@ -0,0 +1,153 @@
+package rdstation
+
+import (
+	"context"
+)
+
+// Iterator walks a paginated resource. The next page is requested in the
+// background as soon as the current one is received, so it is usually ready
+// when the consumer gets to it.
+//
+//	it := rd.SegmentationContacts(ctx, id, 100)
+//	for it.Next() {
+//		lead := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	ctx  context.Context
+	next func(ctx context.Context) (items []T, more bool, err error)
+
+	pending chan page[T]
+	items   []T
+	current T
+	more    bool
+	err     error
+}
+
+type page[T any] struct {
+	items []T
+	more  bool
+	err   error
+}
+
+func newIterator[T any](ctx context.Context, next func(ctx context.Context) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:  ctx,
+		next: next,
+		more: true,
+	}
+}
+
+// NewPageIterator iterates over a resource paginated by page number, starting
+// at page 1. A page with fewer than pageSize items is the last one, pageSize
+// is at least 1.
+func NewPageIterator[T any](ctx context.Context, pageSize int, fetch func(ctx context.Context, page, pageSize int) ([]T, error)) *Iterator[T] {
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	number := 0
+
+	return newIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		number++
+		items, err := fetch(ctx, number, pageSize)
+
+		return items, len(items) > 0 && len(items) >= pageSize, err
+	})
+}
+
+// NewLinkIterator iterates over a resource where each page links to the
+// next one. fetch returns the items at url and the url of the following
+// page, empty on the last one.
+func NewLinkIterator[T any](ctx context.Context, first string, fetch func(ctx context.Context, url string) (items []T, next string, err error)) *Iterator[T] {
+	url := first
+
+	return newIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, next, err := fetch(ctx, url)
+		url = next
+
+		return items, next != "", err
+	})
+}
+
+// filter returns an iterator over the items of it accepted by keep. It reads
+// the pages of it directly, so it must not be iterated itself.
+func filter[T any](it *Iterator[T], keep func(T) bool) *Iterator[T] {
+	return newIterator(it.ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, more, err := it.next(ctx)
+
+		kept := items[:0]
+		for _, item := range items {
+			if keep(item) {
+				kept = append(kept, item)
+			}
+		}
+
+		return kept, more, err
+	})
+}
+
+func (it *Iterator[T]) Next() bool {
+	if it.err == nil {
+		it.err = it.ctx.Err()
+	}
+
+	for len(it.items) == 0 {
+		if it.err != nil || (it.pending == nil && !it.more) {
+			var zero T
+			it.current = zero
+			return false
+		}
+
+		if it.pending == nil {
+			it.prefetch()
+		}
+
+		select {
+		case <-it.ctx.Done():
+			it.err = it.ctx.Err()
+		case p := <-it.pending:
+			it.pending = nil
+			it.items, it.more, it.err = p.items, p.more, p.err
+			if it.err == nil && it.more {
+				it.prefetch()
+			}
+		}
+	}
+
+	it.current = it.items[0]
+	it.items = it.items[1:]
+
+	return true
+}
+
+// prefetch requests the next page. The channel is buffered so the request
+// finishes even if the iterator is abandoned.
+func (it *Iterator[T]) prefetch() {
+	pending := make(chan page[T], 1)
+	it.pending = pending
+
+	go func() {
+		items, more, err := it.next(it.ctx)
+		pending <- page[T]{items: items, more: more, err: err}
+	}()
+}
+
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Collect reads the iterator into a slice, stopping after max items when
+// max is positive.
+func Collect[T any](it *Iterator[T], max int) ([]T, error) {
+	var items []T
+	for (max <= 0 || len(items) < max) && it.Next() {
+		items = append(items, it.Value())
+	}
+
+	return items, it.Err()
+}
@@ -0,0 +1,116 @@
+package rdstation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageIterator(t *testing.T) {
+	requested := make(chan int, 10)
+	it := NewPageIterator(context.Background(), 2, func(ctx context.Context, page, pageSize int) ([]int, error) {
+		requested <- page
+		if page == 3 {
+			return []int{5}, nil
+		}
+
+		return []int{page*2 - 1, page * 2}, nil
+	})
+
+	require.True(t, it.Next())
+	require.Equal(t, 1, it.Value())
+	require.Equal(t, 1, <-requested)
+	// the second page is fetched while the first is consumed
+	require.Equal(t, 2, <-requested)
+
+	got, err := Collect(it, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 3, 4, 5}, got)
+	require.Equal(t, 3, <-requested)
+	require.Empty(t, requested)
+	require.False(t, it.Next())
+}
+
+func TestPageIterator_ZeroPageSize(t *testing.T) {
+	calls := 0
+	it := NewPageIterator(context.Background(), 0, func(ctx context.Context, page, pageSize int) ([]int, error) {
+		calls++
+		require.Equal(t, 1, pageSize)
+		if page > 2 {
+			return nil, nil
+		}
+
+		return []int{page}, nil
+	})
+
+	got, err := Collect(it, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, got)
+	require.Equal(t, 3, calls)
+}
+
+func TestLinkIterator(t *testing.T) {
+	pages := map[string][]string{
+		"/items?cursor=":  {"a", "b"},
+		"/items?cursor=2": {"c"},
+	}
+
+	it := NewLinkIterator(context.Background(), "/items?cursor=", func(ctx context.Context, url string) ([]string, string, error) {
+		next := ""
+		if url == "/items?cursor=" {
+			next = "/items?cursor=2"
+		}
+
+		return pages[url], next, nil
+	})
+
+	got, err := Collect(it, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, got)
+}
+
+func TestIterator_Errors(t *testing.T) {
+	t.Run("fetch error", func(t *testing.T) {
+		target := errors.New("batata")
+		it := NewPageIterator(context.Background(), 1, func(ctx context.Context, page, pageSize int) ([]int, error) {
+			if page == 2 {
+				return nil, target
+			}
+			return []int{page}, nil
+		})
+
+		got, err := Collect(it, 0)
+		require.ErrorIs(t, err, target)
+		require.Equal(t, []int{1}, got)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		it := NewPageIterator(ctx, 1, func(ctx context.Context, page, pageSize int) ([]int, error) {
+			return []int{page}, nil
+		})
+
+		require.True(t, it.Next())
+		cancel()
+		require.False(t, it.Next())
+		require.ErrorIs(t, it.Err(), context.Canceled)
+	})
+}
+
+func TestCollect_Max(t *testing.T) {
+	it := NewPageIterator(context.Background(), 10, func(ctx context.Context, page, pageSize int) ([]string, error) {
+		items := make([]string, pageSize)
+		for i := range items {
+			items[i] = fmt.Sprint(page, "-", i)
+		}
+		return items, nil
+	})
+
+	got, err := Collect(it, 15)
+	require.NoError(t, err)
+	require.Len(t, got, 15)
+	require.Equal(t, "2-4", got[14])
+}
@@ -0,0 +1,62 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE
+package rdstation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type LandingPageService interface {
+	ListLandingPages(ctx context.Context) *Iterator[entity.LandingPage]
+	ListPopups(ctx context.Context) *Iterator[entity.Popup]
+	ConversionIdentifiers(ctx context.Context) (map[string]string, error)
+}
+
+func (rd rdStation) ListLandingPages(ctx context.Context) *Iterator[entity.LandingPage] {
+	return NewPageIterator(ctx, MaxPageSize, func(ctx context.Context, page, pageSize int) ([]entity.LandingPage, error) {
+		var landingPages []entity.LandingPage
+		err := rd.get(pageURL(fmt.Sprintf("%s%s", RDURL, RDLandingPagesPath), page, pageSize), &landingPages)
+
+		return landingPages, err
+	})
+}
+
+func (rd rdStation) ListPopups(ctx context.Context) *Iterator[entity.Popup] {
+	return NewPageIterator(ctx, MaxPageSize, func(ctx context.Context, page, pageSize int) ([]entity.Popup, error) {
+		var popups []entity.Popup
+		err := rd.get(pageURL(fmt.Sprintf("%s%s", RDURL, RDPopupsPath), page, pageSize), &popups)
+
+		return popups, err
+	})
+}
+
+// ConversionIdentifiers maps the conversion identifier of every landing page
+// and popup of the account to its asset type, so identifiers can be checked
+// before sending conversions.
+func (rd rdStation) ConversionIdentifiers(ctx context.Context) (map[string]string, error) {
+	identifiers := map[string]string{}
+
+	landingPages := rd.ListLandingPages(ctx)
+	for landingPages.Next() {
+		if identifier := landingPages.Value().ConversionIdentifier; identifier != "" {
+			identifiers[identifier] = entity.AssetLandingPage
+		}
+	}
+	if err := landingPages.Err(); err != nil {
+		return nil, err
+	}
+
+	popups := rd.ListPopups(ctx)
+	for popups.Next() {
+		if identifier := popups.Value().ConversionIdentifier; identifier != "" {
+			identifiers[identifier] = entity.AssetPopup
+		}
+	}
+	if err := popups.Err(); err != nil {
+		return nil, err
+	}
+
+	return identifiers, nil
+}
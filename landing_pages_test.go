@@ -0,0 +1,71 @@
+package rdstation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_ListLandingPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	url := pageURL(fmt.Sprintf("%s%s", RDURL, RDLandingPagesPath), 1, MaxPageSize)
+	client.EXPECT().Request(url, http.MethodGet, nil).Return([]byte(`[
+		{"id": 1, "title": "Demo", "conversion_identifier": "lp-demo", "status": "PUBLISHED"},
+		{"id": 2, "title": "Ebook", "conversion_identifier": "lp-ebook", "status": "UNPUBLISHED"}
+	]`), nil)
+
+	got, err := Collect(rd.ListLandingPages(context.Background()), 0)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, "lp-demo", got[0].ConversionIdentifier)
+	require.Equal(t, entity.AssetStatusUnpublished, got[1].Status)
+}
+
+func TestRdStation_ConversionIdentifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	landingPages := pageURL(fmt.Sprintf("%s%s", RDURL, RDLandingPagesPath), 1, MaxPageSize)
+	popups := pageURL(fmt.Sprintf("%s%s", RDURL, RDPopupsPath), 1, MaxPageSize)
+
+	t.Run("success", func(t *testing.T) {
+		client.EXPECT().Request(landingPages, http.MethodGet, nil).
+			Return([]byte(`[{"id": 1, "conversion_identifier": "lp-demo"}, {"id": 2}]`), nil)
+		client.EXPECT().Request(popups, http.MethodGet, nil).
+			Return([]byte(`[{"id": 3, "conversion_identifier": "popup-saida"}]`), nil)
+
+		got, err := rd.ConversionIdentifiers(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"lp-demo":     entity.AssetLandingPage,
+			"popup-saida": entity.AssetPopup,
+		}, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client.EXPECT().Request(landingPages, http.MethodGet, nil).Return([]byte(`[]`), nil)
+		client.EXPECT().Request(popups, http.MethodGet, nil).Return(nil, errors.New("batata"))
+
+		got, err := rd.ConversionIdentifiers(context.Background())
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+}
@@ -0,0 +1,247 @@
+package leadcsv
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+type Format int
+
+const (
+	CSV Format = iota
+	JSONL
+)
+
+// Columns is the fixed part of the export schema, custom fields requested in
+// ExportConfig are appended after them.
+var Columns = []string{
+	FieldUuid, FieldName, FieldEmail, FieldJobTitle, FieldBio, FieldWebsite,
+	FieldPersonalPhone, FieldMobilePhone, FieldCity, FieldState, FieldCountry,
+	FieldTwitter, FieldFacebook, FieldLinkedin, FieldTags, FieldExtraEmails,
+}
+
+type ExportConfig struct {
+	Format Format
+	// Delimiter between csv columns, defaults to ','.
+	Delimiter rune
+	// ListSeparator joins tags and extra emails in a csv cell, defaults to ','.
+	ListSeparator string
+	// CustomFields lists the cf_* fields to export, in column order.
+	CustomFields []string
+	// Concurrency is the number of leads fetched at the same time.
+	Concurrency int
+}
+
+// ExportError is a contact that could not be fetched.
+type ExportError struct {
+	Identifier string
+	Err        error
+}
+
+type ExportSummary struct {
+	Exported int
+	Failed   []ExportError
+}
+
+type fetched struct {
+	identifier string
+	lead       *entity.Lead
+	err        error
+}
+
+// Export fetches each contact, identified by email or uuid, and writes it to
+// w as soon as every contact before it was written. Identifiers containing an
+// @ are looked up by email, the others by uuid.
+func Export(ctx context.Context, rd rdstation.RDStation, identifiers []string, w io.Writer, config ExportConfig) (ExportSummary, error) {
+	var summary ExportSummary
+
+	encoder, err := newEncoder(w, config)
+	if err != nil {
+		return summary, err
+	}
+
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// pending keeps the fetches in input order. A slot of sem is taken
+	// before each fetch and given back once its lead is written, so it bounds
+	// both the requests in flight and the leads held in memory.
+	pending := make(chan chan fetched, concurrency)
+	sem := make(chan struct{}, concurrency)
+	go func() {
+		defer close(pending)
+
+		for _, identifier := range identifiers {
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			result := make(chan fetched, 1)
+			pending <- result
+
+			go func(identifier string) {
+				lead, err := fetch(rd, identifier)
+				result <- fetched{identifier: identifier, lead: lead, err: err}
+			}(identifier)
+		}
+	}()
+
+	for result := range pending {
+		f := <-result
+		<-sem
+		if f.err != nil {
+			summary.Failed = append(summary.Failed, ExportError{Identifier: f.identifier, Err: f.err})
+			continue
+		}
+
+		err = encoder.encode(f.lead)
+		if err != nil {
+			return summary, err
+		}
+		summary.Exported++
+	}
+
+	err = encoder.flush()
+	if err != nil {
+		return summary, err
+	}
+
+	return summary, ctx.Err()
+}
+
+func fetch(rd rdstation.RDStation, identifier string) (*entity.Lead, error) {
+	if strings.Contains(identifier, "@") {
+		return rd.GetLeadByEmail(identifier)
+	}
+
+	return rd.GetLeadByUUID(identifier)
+}
+
+type encoder struct {
+	config  ExportConfig
+	columns []string
+	csv     *csv.Writer
+	w       io.Writer
+}
+
+func newEncoder(w io.Writer, config ExportConfig) (*encoder, error) {
+	columns := append(append([]string{}, Columns...), config.CustomFields...)
+	for _, field := range config.CustomFields {
+		if !strings.HasPrefix(field, entity.CustomFieldPrefix) {
+			return nil, fmt.Errorf("leadcsv: %q is not a custom field", field)
+		}
+	}
+
+	e := &encoder{config: config, columns: columns, w: w}
+	if config.Format == JSONL {
+		return e, nil
+	}
+
+	e.csv = csv.NewWriter(w)
+	if config.Delimiter != 0 {
+		e.csv.Comma = config.Delimiter
+	}
+
+	return e, e.csv.Write(columns)
+}
+
+func (e *encoder) encode(lead *entity.Lead) error {
+	if e.csv != nil {
+		return e.csv.Write(e.record(lead))
+	}
+
+	return e.jsonLine(lead)
+}
+
+func (e *encoder) flush() error {
+	if e.csv == nil {
+		return nil
+	}
+
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *encoder) record(lead *entity.Lead) []string {
+	separator := e.config.ListSeparator
+	if separator == "" {
+		separator = ","
+	}
+
+	record := []string{
+		lead.Uuid, lead.Name, lead.Email, lead.JobTitle, lead.Bio, lead.Website,
+		lead.PersonalPhone, lead.MobilePhone, lead.City, lead.State, lead.Country,
+		lead.Twitter, lead.Facebook, lead.Linkedin,
+		strings.Join(lead.Tags, separator), strings.Join(lead.ExtraEmails, separator),
+	}
+
+	for _, field := range e.config.CustomFields {
+		value, ok := lead.CustomFields[field]
+		if !ok || value == nil {
+			record = append(record, "")
+			continue
+		}
+
+		record = append(record, fmt.Sprint(value))
+	}
+
+	return record
+}
+
+// jsonLine writes the lead as an object holding every column, in schema
+// order, so all lines share the same keys.
+func (e *encoder) jsonLine(lead *entity.Lead) error {
+	tags, extraEmails := lead.Tags, lead.ExtraEmails
+	if tags == nil {
+		tags = []string{}
+	}
+	if extraEmails == nil {
+		extraEmails = []string{}
+	}
+
+	values := []interface{}{
+		lead.Uuid, lead.Name, lead.Email, lead.JobTitle, lead.Bio, lead.Website,
+		lead.PersonalPhone, lead.MobilePhone, lead.City, lead.State, lead.Country,
+		lead.Twitter, lead.Facebook, lead.Linkedin, tags, extraEmails,
+	}
+	for _, field := range e.config.CustomFields {
+		values = append(values, lead.CustomFields[field])
+	}
+
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+
+	_, err := e.w.Write(line.Bytes())
+	return err
+}
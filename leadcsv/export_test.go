@@ -0,0 +1,134 @@
+package leadcsv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+func (f *fakeRD) GetLeadByEmail(email string) (*entity.Lead, error) {
+	lead, ok := f.leads[email]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return lead, nil
+}
+
+func (f *fakeRD) GetLeadByUUID(uuid string) (*entity.Lead, error) {
+	for _, lead := range f.leads {
+		if lead.Uuid == uuid {
+			return lead, nil
+		}
+	}
+
+	return nil, errors.New("not found")
+}
+
+func exportRD() *fakeRD {
+	return &fakeRD{leads: map[string]*entity.Lead{
+		"biro@email.com": {
+			Uuid:         "1",
+			Name:         "biro",
+			Email:        "biro@email.com",
+			Tags:         []string{"doce", "solidao"},
+			CustomFields: map[string]interface{}{"cf_plano": "anual"},
+		},
+		"loco@email.com": {
+			Uuid:        "2",
+			Email:       "loco@email.com",
+			ExtraEmails: []string{"outro@email.com"},
+		},
+	}}
+}
+
+func TestExport_CSV(t *testing.T) {
+	var out bytes.Buffer
+
+	summary, err := Export(context.Background(), exportRD(), []string{"biro@email.com", "faltando@email.com", "2"}, &out, ExportConfig{
+		CustomFields:  []string{"cf_plano"},
+		ListSeparator: "|",
+		Concurrency:   2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.Exported)
+	require.Len(t, summary.Failed, 1)
+	require.Equal(t, "faltando@email.com", summary.Failed[0].Identifier)
+
+	require.Equal(t, strings.Join([]string{
+		"uuid,name,email,job_title,bio,website,personal_phone,mobile_phone,city,state,country,twitter,facebook,linkedin,tags,extra_emails,cf_plano",
+		"1,biro,biro@email.com,,,,,,,,,,,,doce|solidao,,anual",
+		"2,,loco@email.com,,,,,,,,,,,,,outro@email.com,",
+		"",
+	}, "\n"), out.String())
+}
+
+func TestExport_JSONL(t *testing.T) {
+	var out bytes.Buffer
+
+	summary, err := Export(context.Background(), exportRD(), []string{"2", "biro@email.com"}, &out, ExportConfig{
+		Format:       JSONL,
+		CustomFields: []string{"cf_plano"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.Exported)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], `{"uuid":"2","name":"","email":"loco@email.com"`))
+	require.True(t, strings.HasSuffix(lines[0], `"tags":[],"extra_emails":["outro@email.com"],"cf_plano":null}`))
+	require.True(t, strings.HasSuffix(lines[1], `"tags":["doce","solidao"],"extra_emails":[],"cf_plano":"anual"}`))
+}
+
+// slowRD records how many leads are being fetched at the same time.
+type slowRD struct {
+	*fakeRD
+
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (s *slowRD) GetLeadByEmail(email string) (*entity.Lead, error) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.max {
+		s.max = s.inFlight
+	}
+	s.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+
+	return s.fakeRD.GetLeadByEmail(email)
+}
+
+func TestExport_Concurrency(t *testing.T) {
+	rd := &slowRD{fakeRD: exportRD()}
+
+	identifiers := make([]string, 20)
+	for i := range identifiers {
+		identifiers[i] = "biro@email.com"
+	}
+
+	summary, err := Export(context.Background(), rd, identifiers, &bytes.Buffer{}, ExportConfig{Concurrency: 3})
+	require.NoError(t, err)
+	require.Equal(t, len(identifiers), summary.Exported)
+	require.Equal(t, 3, rd.max)
+}
+
+func TestExport_InvalidCustomField(t *testing.T) {
+	_, err := Export(context.Background(), exportRD(), nil, &bytes.Buffer{}, ExportConfig{CustomFields: []string{"plano"}})
+	require.Error(t, err)
+}
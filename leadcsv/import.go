@@ -0,0 +1,71 @@
+package leadcsv
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/flan6/rdstation"
+)
+
+// ImportSummary counts the rows processed by Import.
+type ImportSummary struct {
+	Imported int
+	Failed   int
+}
+
+// Import upserts every valid row of r. Rows that fail validation or are
+// rejected by RD Station are written to report with the original columns
+// plus the line they came from and an error column, so the file can be fixed
+// and imported again. Lines that are not valid csv have no columns. The
+// report uses the delimiter and encoding of r.
+func Import(rd rdstation.RDStation, r io.Reader, report io.Writer, config Config) (ImportSummary, error) {
+	var summary ImportSummary
+
+	reader, err := NewReader(r, config)
+	if err != nil {
+		return summary, err
+	}
+
+	if config.Encoding == Latin1 {
+		report = &latin1Writer{w: report}
+	}
+
+	writer := csv.NewWriter(report)
+	writer.Comma = config.delimiter()
+	defer writer.Flush()
+
+	err = writer.Write(append(append([]string{}, reader.Header()...), "line", "error"))
+	if err != nil {
+		return summary, err
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		if row.Err == nil {
+			_, row.Err = rd.UpsertLead(row.Lead)
+		}
+
+		if row.Err == nil {
+			summary.Imported++
+			continue
+		}
+
+		summary.Failed++
+		err = writer.Write(append(append([]string{}, row.Record...), strconv.Itoa(row.Line), row.Err.Error()))
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	writer.Flush()
+
+	return summary, writer.Error()
+}
@@ -0,0 +1,66 @@
+package leadcsv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+type fakeRD struct {
+	rdstation.RDStation
+
+	leads map[string]*entity.Lead
+}
+
+func (f *fakeRD) UpsertLead(lead *entity.Lead) (*entity.Lead, error) {
+	if lead.Email == "recusado@email.com" {
+		return nil, errors.New("batata")
+	}
+
+	f.leads[lead.Email] = lead
+
+	return lead, nil
+}
+
+func TestImport(t *testing.T) {
+	input := "name,email\n" +
+		"biro,biro@email.com\n" +
+		"sem email,\n" +
+		"recusado,recusado@email.com\n"
+
+	rd := &fakeRD{leads: map[string]*entity.Lead{}}
+	var report bytes.Buffer
+
+	summary, err := Import(rd, strings.NewReader(input), &report, Config{})
+	require.NoError(t, err)
+	require.Equal(t, ImportSummary{Imported: 1, Failed: 2}, summary)
+	require.Contains(t, rd.leads, "biro@email.com")
+
+	require.Equal(t, "name,email,line,error\n"+
+		"sem email,,3,invalid email: missing email\n"+
+		"recusado,recusado@email.com,4,batata\n", report.String())
+}
+
+func TestImport_Report(t *testing.T) {
+	input := []byte("name;email\n" +
+		"Jo\xe3o;joao\n" +
+		"\"aspas;quebrado@email.com\n")
+
+	rd := &fakeRD{leads: map[string]*entity.Lead{}}
+	var report bytes.Buffer
+
+	summary, err := Import(rd, bytes.NewReader(input), &report, Config{Delimiter: ';', Encoding: Latin1})
+	require.NoError(t, err)
+	require.Equal(t, ImportSummary{Failed: 2}, summary)
+
+	lines := strings.Split(report.String(), "\n")
+	require.Equal(t, "name;email;line;error", lines[0])
+	require.Equal(t, "Jo\xe3o;joao;2;\"invalid email: \"\"joao\"\"\"", lines[1])
+	require.True(t, strings.HasPrefix(lines[2], "3;"), lines[2])
+}
@@ -0,0 +1,334 @@
+// Package leadcsv converts spreadsheets of contacts to and from entity.Lead.
+package leadcsv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type Encoding int
+
+const (
+	UTF8 Encoding = iota
+	Latin1
+)
+
+// Field names accepted in Config.Mapping, besides any cf_* custom field.
+const (
+	FieldUuid          = "uuid"
+	FieldName          = "name"
+	FieldEmail         = "email"
+	FieldJobTitle      = "job_title"
+	FieldBio           = "bio"
+	FieldWebsite       = "website"
+	FieldPersonalPhone = "personal_phone"
+	FieldMobilePhone   = "mobile_phone"
+	FieldCity          = "city"
+	FieldState         = "state"
+	FieldCountry       = "country"
+	FieldTwitter       = "twitter"
+	FieldFacebook      = "facebook"
+	FieldLinkedin      = "linkedin"
+	FieldTags          = "tags"
+	FieldExtraEmails   = "extra_emails"
+)
+
+var ErrInvalidEmail = errors.New("invalid email")
+
+type Config struct {
+	// Delimiter between columns, defaults to ','.
+	Delimiter rune
+	Encoding  Encoding
+	// Mapping translates a header to a lead field. Headers that are not
+	// mapped are used as field names as long as they are known fields or
+	// custom fields, the rest of the columns are ignored.
+	Mapping map[string]string
+	// ListSeparator splits tags and extra emails inside a cell, defaults to ','.
+	ListSeparator string
+}
+
+func (c Config) delimiter() rune {
+	if c.Delimiter == 0 {
+		return ','
+	}
+
+	return c.Delimiter
+}
+
+func (c Config) listSeparator() string {
+	if c.ListSeparator == "" {
+		return ","
+	}
+
+	return c.ListSeparator
+}
+
+func (c Config) field(header string) string {
+	if field, ok := c.Mapping[header]; ok {
+		return field
+	}
+
+	field := strings.ToLower(strings.TrimSpace(header))
+	if knownField(field) {
+		return field
+	}
+
+	return ""
+}
+
+func knownField(field string) bool {
+	if strings.HasPrefix(field, entity.CustomFieldPrefix) {
+		return true
+	}
+
+	switch field {
+	case FieldUuid, FieldName, FieldEmail, FieldJobTitle, FieldBio, FieldWebsite,
+		FieldPersonalPhone, FieldMobilePhone, FieldCity, FieldState, FieldCountry,
+		FieldTwitter, FieldFacebook, FieldLinkedin, FieldTags, FieldExtraEmails:
+		return true
+	}
+
+	return false
+}
+
+// Row is a parsed line of the file. Err is set when the line does not hold a
+// valid lead, in which case Lead may be partially filled.
+type Row struct {
+	Line   int
+	Record []string
+	Lead   *entity.Lead
+	Err    error
+}
+
+type Reader struct {
+	config Config
+	csv    *csv.Reader
+	header []string
+	fields []string
+}
+
+// NewReader reads the header line and returns a Reader positioned on the
+// first data line.
+func NewReader(r io.Reader, config Config) (*Reader, error) {
+	if config.Encoding == Latin1 {
+		r = &latin1Reader{r: bufio.NewReader(r)}
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = config.delimiter()
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	fields := make([]string, len(header))
+	hasEmail := false
+	for i, h := range header {
+		fields[i] = config.field(h)
+		if fields[i] != "" && !knownField(fields[i]) {
+			return nil, fmt.Errorf("leadcsv: header %q mapped to unknown field %q", h, fields[i])
+		}
+		hasEmail = hasEmail || fields[i] == FieldEmail
+	}
+
+	if !hasEmail {
+		return nil, errors.New("leadcsv: no email column")
+	}
+
+	return &Reader{
+		config: config,
+		csv:    reader,
+		header: header,
+		fields: fields,
+	}, nil
+}
+
+func (r *Reader) Header() []string {
+	return r.header
+}
+
+// Read returns the next row, or io.EOF once the file is over. Invalid rows
+// are returned with Row.Err set and do not stop the reader.
+func (r *Reader) Read() (Row, error) {
+	record, err := r.csv.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return Row{Line: parseErr.StartLine, Record: record, Err: err}, nil
+		}
+
+		return Row{}, err
+	}
+
+	line, _ := r.csv.FieldPos(0)
+	lead, err := r.lead(record)
+
+	return Row{Line: line, Record: record, Lead: lead, Err: err}, nil
+}
+
+func (r *Reader) lead(record []string) (*entity.Lead, error) {
+	lead := &entity.Lead{}
+	for i, value := range record {
+		if i >= len(r.fields) || r.fields[i] == "" {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		r.set(lead, r.fields[i], value)
+	}
+
+	return lead, validate(lead)
+}
+
+func (r *Reader) set(lead *entity.Lead, field, value string) {
+	switch field {
+	case FieldUuid:
+		lead.Uuid = value
+	case FieldName:
+		lead.Name = value
+	case FieldEmail:
+		lead.Email = value
+	case FieldJobTitle:
+		lead.JobTitle = value
+	case FieldBio:
+		lead.Bio = value
+	case FieldWebsite:
+		lead.Website = value
+	case FieldPersonalPhone:
+		lead.PersonalPhone = value
+	case FieldMobilePhone:
+		lead.MobilePhone = value
+	case FieldCity:
+		lead.City = value
+	case FieldState:
+		lead.State = value
+	case FieldCountry:
+		lead.Country = value
+	case FieldTwitter:
+		lead.Twitter = value
+	case FieldFacebook:
+		lead.Facebook = value
+	case FieldLinkedin:
+		lead.Linkedin = value
+	case FieldTags:
+		lead.Tags = append(lead.Tags, r.split(value)...)
+	case FieldExtraEmails:
+		lead.ExtraEmails = append(lead.ExtraEmails, r.split(value)...)
+	default:
+		if lead.CustomFields == nil {
+			lead.CustomFields = map[string]interface{}{}
+		}
+		lead.CustomFields[field] = value
+	}
+}
+
+func (r *Reader) split(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, r.config.listSeparator()) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func validate(lead *entity.Lead) error {
+	if lead.Email == "" {
+		return fmt.Errorf("%w: missing email", ErrInvalidEmail)
+	}
+
+	emails := append([]string{lead.Email}, lead.ExtraEmails...)
+	for _, email := range emails {
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+		}
+	}
+
+	return nil
+}
+
+// latin1Reader decodes ISO-8859-1 into UTF-8. Every byte maps to the rune
+// with the same value.
+type latin1Reader struct {
+	r       *bufio.Reader
+	pending []byte
+}
+
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(l.pending) > 0 {
+			c := copy(p[n:], l.pending)
+			l.pending = l.pending[c:]
+			n += c
+			continue
+		}
+
+		b, err := l.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+
+		if b < utf8.RuneSelf {
+			p[n] = b
+			n++
+			continue
+		}
+
+		l.pending = utf8.AppendRune(nil, rune(b))
+	}
+
+	return n, nil
+}
+
+// latin1Writer encodes UTF-8 into ISO-8859-1. Runes outside of it are
+// written as '?'.
+type latin1Writer struct {
+	w       io.Writer
+	pending []byte
+}
+
+func (l *latin1Writer) Write(p []byte) (int, error) {
+	data := append(l.pending, p...)
+	encoded := make([]byte, 0, len(data))
+	for len(data) > 0 && utf8.FullRune(data) {
+		r, size := utf8.DecodeRune(data)
+		if r > 0xff {
+			r = '?'
+		}
+		encoded = append(encoded, byte(r))
+		data = data[size:]
+	}
+	// a rune split between writes is completed by the next one
+	l.pending = append([]byte(nil), data...)
+
+	_, err := l.w.Write(encoded)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,78 @@
+package leadcsv
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+func readAll(t *testing.T, reader *Reader) []Row {
+	var rows []Row
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestReader(t *testing.T) {
+	input := "Nome;E-mail;tags;cf_plano;ignorada\n" +
+		"biro;biro@email.com;doce, solidao;anual;x\n" +
+		"sem email;;;;\n" +
+		"ruim;nao-e-email;;;\n"
+
+	reader, err := NewReader(strings.NewReader(input), Config{
+		Delimiter:     ';',
+		Mapping:       map[string]string{"Nome": FieldName, "E-mail": FieldEmail},
+		ListSeparator: ",",
+	})
+	require.NoError(t, err)
+
+	rows := readAll(t, reader)
+	require.Len(t, rows, 3)
+
+	require.NoError(t, rows[0].Err)
+	require.Equal(t, 2, rows[0].Line)
+	require.Equal(t, &entity.Lead{
+		Name:         "biro",
+		Email:        "biro@email.com",
+		Tags:         []string{"doce", "solidao"},
+		CustomFields: map[string]interface{}{"cf_plano": "anual"},
+	}, rows[0].Lead)
+
+	require.ErrorIs(t, rows[1].Err, ErrInvalidEmail)
+	require.ErrorIs(t, rows[2].Err, ErrInvalidEmail)
+	require.Equal(t, 4, rows[2].Line)
+}
+
+func TestReader_Latin1(t *testing.T) {
+	input := []byte("name,email,city\nJo\xe3o,joao@email.com,S\xe3o Paulo\n")
+
+	reader, err := NewReader(bytes.NewReader(input), Config{Encoding: Latin1})
+	require.NoError(t, err)
+
+	rows := readAll(t, reader)
+	require.Len(t, rows, 1)
+	require.NoError(t, rows[0].Err)
+	require.Equal(t, "João", rows[0].Lead.Name)
+	require.Equal(t, "São Paulo", rows[0].Lead.City)
+}
+
+func TestNewReader_Errors(t *testing.T) {
+	_, err := NewReader(strings.NewReader("name,city\n"), Config{})
+	require.Error(t, err)
+
+	_, err = NewReader(strings.NewReader("name,email\n"), Config{Mapping: map[string]string{"name": "nome"}})
+	require.Error(t, err)
+
+	_, err = NewReader(strings.NewReader(""), Config{})
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,51 @@
+package rdstation
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/flan6/rdstation/internal/client"
+)
+
+type Option func(*options)
+
+type options struct {
+	limiter    *client.Limiter
+	httpClient *http.Client
+	attempts   int
+	backoff    time.Duration
+	wrap       func(client.Client) client.Client
+}
+
+// WithRateLimit makes the client start at most requests calls per period.
+// RD Station answers with 429 when an account exceeds its plan limits.
+func WithRateLimit(requests int, per time.Duration) Option {
+	return func(o *options) {
+		o.limiter = client.NewLimiter(requests, per)
+	}
+}
+
+// WithRetry sends a request up to attempts times while it fails with a
+// retriable error, see IsRetriable, waiting backoff and then twice as long
+// between attempts. Requests that create resources may be applied twice if
+// RD Station fails after processing them.
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.attempts = attempts
+		o.backoff = backoff
+	}
+}
+
+// WithHTTPClient sends the token exchange and every api request through
+// httpClient, letting several clients share its transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// IsRetriable reports whether an error returned by the client is transient,
+// such as a 429, a 5xx or a network failure.
+func IsRetriable(err error) bool {
+	return client.IsRetriable(err)
+}
@@ -1,3 +1,4 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE -aux_files=github.com/flan6/rdstation=webhooks.go,github.com/flan6/rdstation=segmentations.go,github.com/flan6/rdstation=analytics.go,github.com/flan6/rdstation=emails.go,github.com/flan6/rdstation=landing_pages.go,github.com/flan6/rdstation=workflows.go,github.com/flan6/rdstation=funnels.go
 package rdstation
 
 import (
@@ -12,25 +13,72 @@ import (
 )
 
 const (
-	RDURL           = "https://api.rd.services/"
-	RDLeadPath      = "platform/contacts/"
-	RefreshTokenURL = "auth/token/"
+	RDURL                     = "https://api.rd.services/"
+	RDLeadPath                = "platform/contacts/"
+	RDEventsPath              = "platform/events"
+	RDBatchPath               = "platform/events/batch"
+	RDWebhooksPath            = "integrations/webhooks"
+	RDSegmentationsPath       = "platform/segmentations"
+	RDEmailAnalyticsPath      = "platform/analytics/emails"
+	RDConversionAnalyticsPath = "platform/analytics/conversions"
+	RDFunnelAnalyticsPath     = "platform/analytics/funnel"
+	RDEmailsPath              = "platform/emails"
+	RDLandingPagesPath        = "platform/landing_pages"
+	RDPopupsPath              = "platform/popups"
+	RDWorkflowsPath           = "platform/workflows"
+	RDAccountInfoPath         = "marketing/account_info"
+	RefreshTokenURL           = "auth/token/"
 )
 
 type RDStation interface {
+	AccountInfo() (*entity.AccountInfo, error)
 	GetLeadByEmail(email string) (*entity.Lead, error)
+	GetLeadByUUID(uuid string) (*entity.Lead, error)
 	DeleteLeadByEmail(email string) error
 	UpdateLead(leads *entity.Lead) error
+	UpsertLead(lead *entity.Lead) (*entity.Lead, error)
 	AddTags(lead *entity.Lead, tags []string) error
 	RemoveTags(lead *entity.Lead, tags []string) error
 	CreateLead(lead *entity.Lead) (*entity.Lead, error)
+	SendEvent(event *entity.Event) error
+	SendEventsBatch(events []entity.Event) []EventResult
+	SendConversion(conversion *entity.Conversion) error
+	SendOrderPlaced(order *entity.Order) error
+	SendOrderPlacedItem(item *entity.OrderItem) error
+	SendCartAbandoned(cart *entity.Cart) error
+	SendCartAbandonedItem(item *entity.CartItem) error
+
+	WebhookService
+	SegmentationService
+	AnalyticsService
+	EmailService
+	LandingPageService
+	WorkflowService
+	FunnelService
 }
 
 type rdStation struct {
-	client client.Client
+	client  client.Client
+	account *client.AccountClient
 }
 
-func NewRDStation(clientID, clientSecret, refreshToken string) RDStation {
+// NewRDStation returns nil when the refresh token can not be exchanged, use
+// New to get the reason.
+func NewRDStation(clientID, clientSecret, refreshToken string, opts ...Option) RDStation {
+	rd, err := New(clientID, clientSecret, refreshToken, opts...)
+	if err != nil {
+		return nil
+	}
+
+	return rd
+}
+
+func New(clientID, clientSecret, refreshToken string, opts ...Option) (RDStation, error) {
+	o := options{httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	secret := entity.Secret{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
@@ -43,14 +91,29 @@ func NewRDStation(clientID, clientSecret, refreshToken string) RDStation {
 		AuthStyle: oauth2.AuthStyleInParams,
 	}
 
-	cl, err := client.NewClient(secret, endpoint)
+	cl, err := client.NewClientWithHTTPClient(secret, endpoint, o.httpClient)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	return &rdStation{
-		client: cl,
+	if o.limiter != nil {
+		cl = client.WithLimiter(cl, o.limiter)
 	}
+
+	if o.attempts > 1 {
+		cl = client.WithRetry(cl, o.attempts, o.backoff)
+	}
+
+	if o.wrap != nil {
+		cl = o.wrap(cl)
+	}
+
+	account := client.WithAccountLookup(cl, lookupAccountID)
+
+	return &rdStation{
+		client:  account,
+		account: account,
+	}, nil
 }
 
 func (rd rdStation) GetLeadByEmail(email string) (*entity.Lead, error) {
@@ -68,6 +131,21 @@ func (rd rdStation) GetLeadByEmail(email string) (*entity.Lead, error) {
 	return &lead, nil
 }
 
+func (rd rdStation) GetLeadByUUID(uuid string) (*entity.Lead, error) {
+	ret, err := rd.client.Request(fmt.Sprintf("%s%s%s", RDURL, RDLeadPath, uuid), http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var lead entity.Lead
+	err = json.Unmarshal(ret, &lead)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lead, nil
+}
+
 func (rd rdStation) CreateLead(lead *entity.Lead) (*entity.Lead, error) {
 	data, err := json.Marshal(lead)
 	if err != nil {
@@ -103,6 +181,26 @@ func (rd rdStation) UpdateLead(lead *entity.Lead) error {
 	return err
 }
 
+func (rd rdStation) UpsertLead(lead *entity.Lead) (*entity.Lead, error) {
+	data, err := json.Marshal(lead)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = rd.client.Request(fmt.Sprintf("%s%semail:%s", RDURL, RDLeadPath, lead.Email), http.MethodPatch, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var upserted entity.Lead
+	err = json.Unmarshal(data, &upserted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upserted, nil
+}
+
 func (rd rdStation) AddTags(lead *entity.Lead, tags []string) error {
 	for _, tag := range tags {
 		if lead.HasTag(tag) {
@@ -158,6 +256,19 @@ func (rd rdStation) RemoveTags(lead *entity.Lead, tags []string) error {
 	return err
 }
 
+func (rd rdStation) get(url string, v interface{}) error {
+	data, err := rd.client.Request(url, http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func pageURL(url string, page, pageSize int) string {
+	return fmt.Sprintf("%s?page=%d&page_size=%d", url, page, pageSize)
+}
+
 func contains(tags []string, tag string) bool {
 	for _, t := range tags {
 		if t == tag {
@@ -57,6 +57,43 @@ func TestRdStation_GetLeadByEmail(t *testing.T) {
 	})
 }
 
+func TestRdStation_GetLeadByUUID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	t.Run("success", func(t *testing.T) {
+		lead := entity.Lead{
+			Uuid:  "aksjdnasd",
+			Name:  "nome",
+			Email: "email",
+		}
+		ret, err := json.Marshal(lead)
+		require.NoError(t, err)
+
+		client.EXPECT().Request(fmt.Sprintf("%s%s%s", RDURL, RDLeadPath, lead.Uuid), http.MethodGet, nil).
+			Return(ret, nil)
+
+		res, err := rd.GetLeadByUUID(lead.Uuid)
+
+		require.NoError(t, err)
+		require.Equal(t, lead, *res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client.EXPECT().Request(fmt.Sprintf("%s%s%s", RDURL, RDLeadPath, "aksjdnasd"), http.MethodGet, nil).
+			Return(nil, errors.New("err"))
+
+		res, err := rd.GetLeadByUUID("aksjdnasd")
+
+		require.Error(t, err)
+		require.Empty(t, res)
+	})
+}
+
 func TestRdStation_CreateLead(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -169,6 +206,44 @@ func TestRdStation_UpdateLead(t *testing.T) {
 	})
 }
 
+func TestRdStation_UpsertLead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	lead := entity.Lead{
+		Name:  "nome",
+		Email: "email",
+	}
+	data, err := json.Marshal(lead)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		expected := entity.Lead{Uuid: "aksjdnasd", Name: "nome", Email: "email"}
+		response, err := json.Marshal(expected)
+		require.NoError(t, err)
+
+		client.EXPECT().Request(fmt.Sprintf("%s%semail:%s", RDURL, RDLeadPath, lead.Email), http.MethodPatch, data).
+			Return(response, nil)
+
+		got, err := rd.UpsertLead(&lead)
+		require.NoError(t, err)
+		require.Equal(t, expected, *got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client.EXPECT().Request(fmt.Sprintf("%s%semail:%s", RDURL, RDLeadPath, lead.Email), http.MethodPatch, data).
+			Return(nil, errors.New("ah sei la"))
+
+		got, err := rd.UpsertLead(&lead)
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+}
+
 func TestRdStation_AddTags(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,214 @@
+// Package cassette records the http traffic of a client to a file and
+// replays it later, so tests captured once against a sandbox account run in
+// CI without credentials or network.
+//
+//	mode := cassette.ModeReplay
+//	if os.Getenv("RDSTATION_RECORD") != "" {
+//		mode = cassette.ModeRecord
+//	}
+//
+//	recorder, err := cassette.New("testdata/leads.json", mode, nil)
+//	defer recorder.Save()
+//
+//	rd, err := rdstation.New(id, secret, refresh, rdstation.WithHTTPClient(recorder.Client()))
+//
+// Access tokens, client secrets and emails are scrubbed before anything is
+// written. Emails are replaced by a stable placeholder, so a replayed lead
+// has a redacted email.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// ModeReplay answers from the cassette file and never reaches the
+	// network.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request and saves the interactions, replacing
+	// the cassette file on Save.
+	ModeRecord
+)
+
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches the request")
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays a cassette. It
+// is safe for concurrent use, though concurrent requests that match the same
+// interactions may be replayed in a different order.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a recorder for the cassette at path. In ModeReplay the file
+// must exist. transport sends the requests being recorded,
+// http.DefaultTransport when nil.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(data, &r.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an http client that goes through the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	request := scrubRequest(req, body)
+
+	if r.mode == ModeReplay {
+		return r.replay(req, request)
+	}
+
+	return r.record(req, request)
+}
+
+// replay answers with the first unused interaction that matches the
+// request, so repeated requests get the responses in the recorded order.
+func (r *Recorder) replay(req *http.Request, request Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := matchKey(request)
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || matchKey(interaction.Request) != key {
+			continue
+		}
+
+		r.used[i] = true
+
+		return interaction.Response.http(req), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, request.Method, request.URL)
+}
+
+func (r *Recorder) record(req *http.Request, request Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  request,
+		Response: scrubResponse(resp, body),
+	})
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+func (r Response) http(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(r.Body))),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
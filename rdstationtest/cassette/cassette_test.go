@@ -0,0 +1,86 @@
+package cassette
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/rdstationtest"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leads.json")
+
+	server := rdstationtest.NewServer()
+	server.AddLead(entity.Lead{Name: "Batata", Email: "batata@example.com"})
+
+	recorder, err := New(path, ModeRecord, server.Client().Transport)
+	require.NoError(t, err)
+
+	rd, err := rdstation.New(rdstationtest.ClientID, rdstationtest.ClientSecret, rdstationtest.RefreshToken,
+		rdstation.WithHTTPClient(recorder.Client()))
+	require.NoError(t, err)
+
+	lead, err := rd.GetLeadByEmail("batata@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "batata@example.com", lead.Email)
+
+	require.NoError(t, rd.AddTags(lead, []string{"cliente"}))
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"batata@example.com", rdstationtest.ClientSecret, rdstationtest.RefreshToken, "rdstationtest-token", "Bearer"} {
+		assert.NotContains(t, string(data), secret)
+	}
+
+	recorder, err = New(path, ModeReplay, nil)
+	require.NoError(t, err)
+
+	rd, err = rdstation.New(rdstationtest.ClientID, rdstationtest.ClientSecret, rdstationtest.RefreshToken,
+		rdstation.WithHTTPClient(recorder.Client()))
+	require.NoError(t, err)
+
+	lead, err = rd.GetLeadByEmail("batata@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Batata", lead.Name)
+	assert.Equal(t, scrubEmails("batata@example.com"), lead.Email)
+
+	// The body is matched too, a different tag was never recorded.
+	err = rd.AddTags(&entity.Lead{Email: "batata@example.com"}, []string{"outra"})
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+
+	require.NoError(t, rd.AddTags(&entity.Lead{Email: "batata@example.com"}, []string{"cliente"}))
+
+	// Each interaction is replayed once.
+	_, err = rd.GetLeadByEmail("batata@example.com")
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+func TestScrubBody(t *testing.T) {
+	body := scrubBody([]byte(`{"refresh_token": "r", "client_id": "id", "leads": [{"email": "Batata@Example.com", "id": 12345678901234567}]}`), "application/json")
+	assert.Equal(t, `{"client_id":"id","leads":[{"email":"`+scrubEmails("batata@example.com")+`","id":12345678901234567}],"refresh_token":"REDACTED"}`, body)
+
+	form := scrubBody([]byte("grant_type=refresh_token&client_secret=s&refresh_token=r&email=batata%40example.com"), "application/x-www-form-urlencoded")
+	assert.Equal(t, "client_secret=REDACTED&email="+url.QueryEscape(scrubEmails("batata@example.com"))+
+		"&grant_type=refresh_token&refresh_token=REDACTED", form)
+	assert.NotContains(t, form, "batata")
+}
+
+func TestScrubURL(t *testing.T) {
+	u, err := url.Parse("https://crm.rdstation.com/api/v1/contacts?email=batata%40example.com&token=t&page=1")
+	require.NoError(t, err)
+
+	scrubbed := scrubURL(u)
+	assert.Equal(t, "https://crm.rdstation.com/api/v1/contacts?email="+url.QueryEscape(scrubEmails("batata@example.com"))+
+		"&page=1&token=REDACTED", scrubbed)
+	assert.NotContains(t, scrubbed, "batata")
+}
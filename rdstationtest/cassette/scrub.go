@@ -0,0 +1,153 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the secrets removed from a cassette.
+const Redacted = "REDACTED"
+
+// secretFields are the json fields, form fields and query parameters whose
+// values are never written.
+var secretFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"token":         true,
+}
+
+// droppedHeaders are left out of requests and responses, either secret or
+// changing on every run.
+var droppedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Date", "Content-Length"}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// scrubEmails replaces every email by a placeholder derived from its hash, so
+// the same email is always scrubbed the same way and requests still match.
+func scrubEmails(s string) string {
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		sum := sha256.Sum256([]byte(strings.ToLower(email)))
+		return "redacted-" + hex.EncodeToString(sum[:4]) + "@example.com"
+	})
+}
+
+func scrubHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range droppedHeaders {
+		header.Del(name)
+	}
+	if len(header) == 0 {
+		return nil
+	}
+
+	return header
+}
+
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	scrubbed.User = nil
+	scrubbed.Path = scrubEmails(u.Path)
+	scrubbed.RawPath = ""
+
+	scrubbed.RawQuery = scrubValues(u.Query()).Encode()
+
+	return scrubbed.String()
+}
+
+// scrubBody removes the secrets of a json or form body. Json is also
+// normalized, with sorted keys and no insignificant spaces.
+func scrubBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&value) == nil && !decoder.More() {
+		data, err := json.Marshal(scrubJSON(value))
+		if err == nil {
+			return scrubEmails(string(data))
+		}
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err == nil {
+			return scrubValues(form).Encode()
+		}
+	}
+
+	return scrubEmails(string(body))
+}
+
+// scrubValues removes the secrets and emails of a query or form. Emails are
+// scrubbed before encoding, which would escape their @.
+func scrubValues(values url.Values) url.Values {
+	for name := range values {
+		for i, value := range values[name] {
+			if secretFields[name] {
+				values[name][i] = Redacted
+				continue
+			}
+			values[name][i] = scrubEmails(value)
+		}
+	}
+
+	return values
+}
+
+func scrubJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if secretFields[name] {
+				v[name] = Redacted
+				continue
+			}
+			v[name] = scrubJSON(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = scrubJSON(item)
+		}
+	}
+
+	return value
+}
+
+func scrubRequest(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		URL:    scrubURL(req.URL),
+		Header: scrubHeader(req.Header),
+		Body:   scrubBody(body, req.Header.Get("Content-Type")),
+	}
+}
+
+func scrubResponse(resp *http.Response, body []byte) Response {
+	return Response{
+		StatusCode: resp.StatusCode,
+		Header:     scrubHeader(resp.Header),
+		Body:       scrubBody(body, resp.Header.Get("Content-Type")),
+	}
+}
+
+// matchKey identifies a request by method, path and normalized body. The
+// query is left out, pages of a list are told apart by the order they were
+// recorded in.
+func matchKey(request Request) string {
+	path := request.URL
+	if u, err := url.Parse(request.URL); err == nil {
+		path = u.Path
+	}
+
+	return request.Method + " " + path + "\n" + request.Body
+}
@@ -0,0 +1,257 @@
+package rdstationtest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+// AddLead stores a lead as if it had been created through the api and
+// returns it with its uuid.
+func (s *Server) AddLead(lead entity.Lead) entity.Lead {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lead.Uuid == "" {
+		lead.Uuid = s.newUUID()
+	}
+	s.leads[strings.ToLower(lead.Email)] = &lead
+
+	return lead
+}
+
+// Lead returns the stored lead with email.
+func (s *Server) Lead(email string) (entity.Lead, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lead, ok := s.leads[strings.ToLower(email)]
+	if !ok {
+		return entity.Lead{}, false
+	}
+
+	return *lead, true
+}
+
+// Leads returns every stored lead, sorted by email.
+func (s *Server) Leads() []entity.Lead {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	leads := make([]entity.Lead, 0, len(s.leads))
+	for _, lead := range s.leads {
+		leads = append(leads, *lead)
+	}
+	sort.Slice(leads, func(i, j int) bool {
+		return leads[i].Email < leads[j].Email
+	})
+
+	return leads
+}
+
+func (s *Server) contacts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed.")
+		return
+	}
+
+	fields, ok := readFields(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var lead entity.Lead
+	if !merge(w, &lead, fields) || !validEmail(w, lead.Email) {
+		return
+	}
+
+	if _, exists := s.leads[strings.ToLower(lead.Email)]; exists {
+		writeError(w, http.StatusUnprocessableEntity, "EMAIL_ALREADY_IN_USE", "Email already in use.")
+		return
+	}
+
+	lead.Uuid = s.newUUID()
+	s.leads[strings.ToLower(lead.Email)] = &lead
+
+	writeJSON(w, http.StatusOK, lead)
+}
+
+// contact serves platform/contacts/{identifier}, where identifier is
+// email:{email}, uuid:{uuid} or a bare uuid, and its tag subresource.
+func (s *Server) contact(w http.ResponseWriter, r *http.Request, identifier string) {
+	tag := strings.HasSuffix(identifier, "/tag")
+	identifier = strings.TrimSuffix(identifier, "/tag")
+	if tag && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed.")
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if r.Method == http.MethodPatch || r.Method == http.MethodPost {
+		var ok bool
+		fields, ok = readFields(w, r)
+		if !ok {
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byEmail := strings.HasPrefix(identifier, "email:")
+	email := strings.TrimPrefix(identifier, "email:")
+	lead := s.find(identifier)
+
+	switch {
+	case tag:
+		if lead == nil {
+			notFound(w)
+			return
+		}
+		s.tag(w, lead, fields)
+	case r.Method == http.MethodGet:
+		if lead == nil {
+			notFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, lead)
+	case r.Method == http.MethodDelete:
+		if lead == nil {
+			notFound(w)
+			return
+		}
+		delete(s.leads, strings.ToLower(lead.Email))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPatch:
+		// Patching by email creates the contact, which is how the api
+		// upserts.
+		if lead == nil && !byEmail {
+			notFound(w)
+			return
+		}
+
+		updated := entity.Lead{Email: email}
+		if lead != nil {
+			updated = *lead
+		}
+		if !merge(w, &updated, fields) || !validEmail(w, updated.Email) {
+			return
+		}
+		if updated.Uuid == "" {
+			updated.Uuid = s.newUUID()
+		}
+
+		if lead != nil {
+			delete(s.leads, strings.ToLower(lead.Email))
+		}
+		s.leads[strings.ToLower(updated.Email)] = &updated
+
+		writeJSON(w, http.StatusOK, updated)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed.")
+	}
+}
+
+func (s *Server) find(identifier string) *entity.Lead {
+	if strings.HasPrefix(identifier, "email:") {
+		return s.leads[strings.ToLower(strings.TrimPrefix(identifier, "email:"))]
+	}
+
+	uuid := strings.TrimPrefix(identifier, "uuid:")
+	for _, lead := range s.leads {
+		if lead.Uuid == uuid {
+			return lead
+		}
+	}
+
+	return nil
+}
+
+// tag adds tags to the lead, lowercased and without repeating them, as the
+// api does.
+func (s *Server) tag(w http.ResponseWriter, lead *entity.Lead, fields map[string]json.RawMessage) {
+	var tags []string
+	err := json.Unmarshal(fields["tags"], &tags)
+	if err != nil || len(tags) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_FIELDS", "Tags must be a non empty list.")
+		return
+	}
+
+	for _, tag := range tags {
+		tag = strings.ToLower(tag)
+		if !lead.HasTag(tag) {
+			lead.Tags = append(lead.Tags, tag)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]string{"tags": lead.Tags})
+}
+
+func readFields(w http.ResponseWriter, r *http.Request) (map[string]json.RawMessage, bool) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return nil, false
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed json.")
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// merge applies the given fields over lead, leaving the others untouched as
+// a PATCH does.
+func merge(w http.ResponseWriter, lead *entity.Lead, fields map[string]json.RawMessage) bool {
+	data, err := json.Marshal(lead)
+	if err == nil {
+		current := map[string]json.RawMessage{}
+		err = json.Unmarshal(data, &current)
+		for name, value := range fields {
+			current[name] = value
+		}
+		if err == nil {
+			data, err = json.Marshal(current)
+		}
+	}
+
+	var merged entity.Lead
+	if err == nil {
+		err = json.Unmarshal(data, &merged)
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_FIELDS", err.Error())
+		return false
+	}
+
+	merged.Uuid = lead.Uuid
+	*lead = merged
+
+	return true
+}
+
+func validEmail(w http.ResponseWriter, email string) bool {
+	_, err := mail.ParseAddress(email)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_EMAIL", "Email is invalid.")
+		return false
+	}
+
+	return true
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Lead not found.")
+}
@@ -0,0 +1,115 @@
+package rdstationtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+// Events returns the events received so far, in order. Their payloads are
+// decoded as generic json objects.
+func (s *Server) Events() []entity.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]entity.Event(nil), s.events...)
+}
+
+type eventResult struct {
+	EventUUID string `json:"event_uuid"`
+}
+
+func (s *Server) event(w http.ResponseWriter, r *http.Request) {
+	var event entity.Event
+	err := json.NewDecoder(r.Body).Decode(&event)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed json.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validEvent(w, event) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.receive(event))
+}
+
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	var events []entity.Event
+	err := json.NewDecoder(r.Body).Decode(&events)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed json.")
+		return
+	}
+
+	if len(events) == 0 || len(events) > rdstation.MaxEventsBatchSize {
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_BATCH_SIZE", "A batch must have between 1 and 25 events.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		if !validEvent(w, event) {
+			return
+		}
+	}
+
+	results := make([]eventResult, len(events))
+	for i, event := range events {
+		results[i] = s.receive(event)
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// receive stores the event. Conversions also create the contact they
+// identify, as they do in RD Station.
+func (s *Server) receive(event entity.Event) eventResult {
+	s.events = append(s.events, event)
+
+	payload, _ := event.Payload.(map[string]interface{})
+	email, _ := payload["email"].(string)
+	if event.EventType == entity.EventTypeConversion && s.leads[strings.ToLower(email)] == nil {
+		name, _ := payload["name"].(string)
+		s.leads[strings.ToLower(email)] = &entity.Lead{
+			Uuid:  s.newUUID(),
+			Name:  name,
+			Email: email,
+		}
+	}
+
+	return eventResult{EventUUID: s.newUUID()}
+}
+
+func validEvent(w http.ResponseWriter, event entity.Event) bool {
+	if event.EventType == "" || event.EventFamily != entity.EventFamilyCDP {
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_FIELDS", "event_type and event_family are required.")
+		return false
+	}
+
+	payload, ok := event.Payload.(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_FIELDS", "payload must be an object.")
+		return false
+	}
+
+	email, _ := payload["email"].(string)
+	if !validEmail(w, email) {
+		return false
+	}
+
+	if event.EventType == entity.EventTypeConversion && payload["conversion_identifier"] == nil {
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_FIELDS", "conversion_identifier is required.")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,164 @@
+package rdstationtest
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault makes the server misbehave on the requests it matches. Each fault
+// counts the requests it matches, so it can be scripted to hit, for instance,
+// only the third call to a route.
+type Fault struct {
+	// Method matches any method when empty.
+	Method string
+	// Path matches requests whose path, without the leading slash, starts
+	// with it, such as "platform/contacts" or "auth/token". Empty matches
+	// every path.
+	Path string
+
+	// After lets the first matching requests through untouched.
+	After int
+	// Times is how many requests fail once After is reached, every following
+	// one when zero.
+	Times int
+
+	// Latency delays the response, combined with any other behavior.
+	Latency time.Duration
+	// Status answers with an api error of this status, such as 429 or 503.
+	Status int
+	// RetryAfter is sent as the Retry-After header, rounded up to whole
+	// seconds.
+	RetryAfter time.Duration
+	// Reset closes the connection without answering. The http transport
+	// resends an idempotent request once when a reused connection is reset,
+	// so a single reset may go unnoticed. Servers whose connections can not
+	// be hijacked, such as HTTP/2 ones, answer 500 instead.
+	Reset bool
+	// MalformedJSON answers 200 with a truncated json body.
+	MalformedJSON bool
+	// ExpireTokens invalidates every access token before the request is
+	// served, as if they had expired mid-session.
+	ExpireTokens bool
+
+	calls int
+}
+
+// WithFaults injects faults from the start, see Server.Inject.
+func WithFaults(faults ...Fault) Option {
+	return func(s *Server) {
+		for i := range faults {
+			fault := faults[i]
+			s.faults = append(s.faults, &fault)
+		}
+	}
+}
+
+// Inject adds a fault. Faults are checked in the order they were added and
+// the first active one applies, though every matching fault counts the
+// request.
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every fault, the server behaves again.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+func (f *Fault) matches(r *http.Request, path string) bool {
+	return (f.Method == "" || f.Method == r.Method) && strings.HasPrefix(path, f.Path)
+}
+
+func (f *Fault) active() bool {
+	return f.calls > f.After && (f.Times == 0 || f.calls <= f.After+f.Times)
+}
+
+func (s *Server) fault(r *http.Request, path string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var applied *Fault
+	for _, fault := range s.faults {
+		if !fault.matches(r, path) {
+			continue
+		}
+
+		fault.calls++
+		if applied == nil && fault.active() {
+			copied := *fault
+			applied = &copied
+		}
+	}
+
+	if applied != nil && applied.ExpireTokens {
+		s.tokens = map[string]time.Time{}
+	}
+
+	return applied
+}
+
+// inject applies the fault and reports whether it already answered the
+// request.
+func (f *Fault) inject(w http.ResponseWriter, r *http.Request) bool {
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	switch {
+	case f.Reset:
+		reset(w)
+	case f.Status != 0:
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(f.RetryAfter.Seconds()))))
+		}
+		writeError(w, f.Status, errorType(f.Status), http.StatusText(f.Status))
+	case f.MalformedJSON:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"uuid":"`))
+	default:
+		return false
+	}
+
+	return true
+}
+
+// reset drops the connection with a TCP reset instead of a clean close.
+func reset(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "FAULT_NOT_SUPPORTED", "Reset fault: the connection can not be hijacked.")
+		return
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "FAULT_NOT_SUPPORTED", "Reset fault: "+err.Error())
+		return
+	}
+
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		_ = tcp.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+// errorType turns a status into the api error type, 503 becomes
+// SERVICE_UNAVAILABLE.
+func errorType(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
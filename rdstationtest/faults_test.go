@@ -0,0 +1,122 @@
+package rdstationtest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+func TestServer_Faults(t *testing.T) {
+	server := NewServer(WithFaults(Fault{
+		Method: http.MethodGet,
+		Path:   rdstation.RDLeadPath,
+		After:  1,
+		Times:  2,
+		Status: http.StatusServiceUnavailable,
+	}))
+	defer server.Close()
+	server.AddLead(entity.Lead{Email: "batata@example.com"})
+
+	rd, err := server.New()
+	require.NoError(t, err)
+
+	_, err = rd.GetLeadByEmail("batata@example.com")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = rd.GetLeadByEmail("batata@example.com")
+		assert.Equal(t, http.StatusServiceUnavailable, statusCode(t, err))
+		assert.True(t, rdstation.IsRetriable(err))
+	}
+
+	_, err = rd.GetLeadByEmail("batata@example.com")
+	require.NoError(t, err)
+
+	t.Run("retry", func(t *testing.T) {
+		server.Inject(Fault{Path: rdstation.RDLeadPath, Times: 2, Status: http.StatusBadGateway})
+
+		rd, err := server.New(rdstation.WithRetry(3, time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = rd.GetLeadByEmail("batata@example.com")
+		require.NoError(t, err)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		server.ClearFaults()
+		server.Inject(Fault{Path: rdstation.RDAccountInfoPath, Status: http.StatusTooManyRequests, RetryAfter: 30 * time.Second})
+
+		_, err := rd.AccountInfo()
+		var rdErr rdstation.RDError
+		require.True(t, errors.As(err, &rdErr))
+		assert.Equal(t, "TOO_MANY_REQUESTS", rdErr.Errors.Type)
+		assert.Equal(t, 30*time.Second, rdErr.Errors.RetryAfter)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		server.ClearFaults()
+		server.Inject(Fault{Reset: true})
+
+		_, err := rd.GetLeadByEmail("batata@example.com")
+		require.Error(t, err)
+		assert.True(t, rdstation.IsRetriable(err))
+	})
+
+	t.Run("malformed json", func(t *testing.T) {
+		server.ClearFaults()
+		server.Inject(Fault{Times: 1, MalformedJSON: true})
+
+		_, err := rd.GetLeadByEmail("batata@example.com")
+		require.Error(t, err)
+		assert.False(t, rdstation.IsRetriable(err))
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		server.ClearFaults()
+		server.Inject(Fault{Times: 1, Latency: 50 * time.Millisecond})
+
+		start := time.Now()
+		_, err := rd.GetLeadByEmail("batata@example.com")
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		server.ClearFaults()
+		server.Inject(Fault{After: 1, Times: 1, ExpireTokens: true})
+
+		_, err := rd.GetLeadByEmail("batata@example.com")
+		require.NoError(t, err)
+
+		_, err = rd.GetLeadByEmail("batata@example.com")
+		assert.Equal(t, http.StatusUnauthorized, statusCode(t, err))
+	})
+}
+
+func TestFault_Inject(t *testing.T) {
+	t.Run("retry after rounds up", func(t *testing.T) {
+		fault := Fault{Status: http.StatusTooManyRequests, RetryAfter: 500 * time.Millisecond}
+
+		recorder := httptest.NewRecorder()
+		require.True(t, fault.inject(recorder, httptest.NewRequest(http.MethodGet, "/", nil)))
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("reset without hijacker", func(t *testing.T) {
+		fault := Fault{Reset: true}
+
+		recorder := httptest.NewRecorder()
+		require.True(t, fault.inject(recorder, httptest.NewRequest(http.MethodGet, "/", nil)))
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "hijacked")
+	})
+}
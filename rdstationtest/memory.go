@@ -0,0 +1,357 @@
+package rdstationtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+var _ rdstation.RDStation = (*Memory)(nil)
+
+// Memory is an RDStation that keeps leads, their tags and the events sent in
+// memory, for unit tests that need no http at all. Failures are reported
+// with the same errors the api returns, such as a 404 for a missing lead.
+//
+// The remaining methods are delegated to the embedded RDStation, which can be
+// set to a MockRDStation to stub them. While it is nil, the default, they
+// return ErrNotImplemented:
+//
+//	memory := rdstationtest.NewMemory()
+//	memory.RDStation = rdstationtest.NewMockRDStation(ctrl)
+type Memory struct {
+	rdstation.RDStation
+
+	mu       sync.Mutex
+	leads    map[string]entity.Lead
+	events   []entity.Event
+	sequence int
+}
+
+func NewMemory(leads ...entity.Lead) *Memory {
+	m := &Memory{leads: map[string]entity.Lead{}}
+	for _, lead := range leads {
+		m.AddLead(lead)
+	}
+
+	return m
+}
+
+// AddLead stores a lead, giving it a uuid when it has none.
+func (m *Memory) AddLead(lead entity.Lead) entity.Lead {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.store(lead)
+}
+
+// Lead returns the stored lead with email.
+func (m *Memory) Lead(email string) (entity.Lead, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lead, ok := m.leads[strings.ToLower(email)]
+
+	return clone(lead), ok
+}
+
+// Leads returns every stored lead, sorted by email.
+func (m *Memory) Leads() []entity.Lead {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	leads := make([]entity.Lead, 0, len(m.leads))
+	for _, lead := range m.leads {
+		leads = append(leads, clone(lead))
+	}
+	sort.Slice(leads, func(i, j int) bool {
+		return leads[i].Email < leads[j].Email
+	})
+
+	return leads
+}
+
+// Events returns the events sent so far, in order.
+func (m *Memory) Events() []entity.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]entity.Event(nil), m.events...)
+}
+
+func (m *Memory) GetLeadByEmail(email string) (*entity.Lead, error) {
+	lead, ok := m.Lead(email)
+	if !ok {
+		return nil, errLeadNotFound
+	}
+
+	return &lead, nil
+}
+
+func (m *Memory) GetLeadByUUID(uuid string) (*entity.Lead, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, lead := range m.leads {
+		if lead.Uuid == uuid {
+			lead = clone(lead)
+			return &lead, nil
+		}
+	}
+
+	return nil, errLeadNotFound
+}
+
+func (m *Memory) DeleteLeadByEmail(email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.leads[strings.ToLower(email)]; !ok {
+		return errLeadNotFound
+	}
+	delete(m.leads, strings.ToLower(email))
+
+	return nil
+}
+
+func (m *Memory) CreateLead(lead *entity.Lead) (*entity.Lead, error) {
+	if err := checkEmail(lead.Email); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.leads[strings.ToLower(lead.Email)]; ok {
+		return nil, apiError(http.StatusUnprocessableEntity, "EMAIL_ALREADY_IN_USE", "Email already in use.")
+	}
+
+	created := m.store(*lead)
+
+	return &created, nil
+}
+
+// UpdateLead changes the fields set in lead, as the api PATCH does.
+func (m *Memory) UpdateLead(lead *entity.Lead) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.leads[strings.ToLower(lead.Email)]
+	if !ok {
+		return errLeadNotFound
+	}
+
+	m.store(patch(stored, lead))
+
+	return nil
+}
+
+// UpsertLead updates the lead with the same email like UpdateLead, or
+// creates it.
+func (m *Memory) UpsertLead(lead *entity.Lead) (*entity.Lead, error) {
+	if err := checkEmail(lead.Email); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upserted := m.store(patch(m.leads[strings.ToLower(lead.Email)], lead))
+
+	return &upserted, nil
+}
+
+// AddTags adds the tags to the lead. Like the client, adding
+// entity.AcademyActive drops entity.AcademyTagCancelled, and the reverse.
+func (m *Memory) AddTags(lead *entity.Lead, tags []string) error {
+	return m.setTags(lead, func(current []string) []string {
+		if contains(tags, entity.AcademyActive) {
+			current = without(current, entity.AcademyTagCancelled)
+		}
+		if contains(tags, entity.AcademyTagCancelled) {
+			current = without(current, entity.AcademyActive)
+		}
+
+		for _, tag := range tags {
+			if !contains(current, tag) {
+				current = append(current, tag)
+			}
+		}
+
+		return current
+	})
+}
+
+func (m *Memory) RemoveTags(lead *entity.Lead, tags []string) error {
+	return m.setTags(lead, func(current []string) []string {
+		for _, tag := range tags {
+			current = without(current, tag)
+		}
+
+		return current
+	})
+}
+
+// setTags changes the tags of the stored lead and mirrors them in lead, as
+// the client updates the lead it is given.
+func (m *Memory) setTags(lead *entity.Lead, change func([]string) []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.leads[strings.ToLower(lead.Email)]
+	if !ok {
+		return errLeadNotFound
+	}
+
+	stored.Tags = change(append([]string(nil), stored.Tags...))
+	m.leads[strings.ToLower(lead.Email)] = stored
+	lead.Tags = append([]string(nil), stored.Tags...)
+
+	return nil
+}
+
+func (m *Memory) SendEvent(event *entity.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, *event)
+
+	return nil
+}
+
+func (m *Memory) SendEventsBatch(events []entity.Event) []rdstation.EventResult {
+	results := make([]rdstation.EventResult, len(events))
+	for i := range events {
+		results[i] = rdstation.EventResult{Index: i, Err: m.SendEvent(&events[i])}
+	}
+
+	return results
+}
+
+// SendConversion records the event and creates the lead when it is new.
+func (m *Memory) SendConversion(conversion *entity.Conversion) error {
+	if err := checkEmail(conversion.Email); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	if _, ok := m.leads[strings.ToLower(conversion.Email)]; !ok {
+		m.store(entity.Lead{Name: conversion.Name, Email: conversion.Email})
+	}
+	m.mu.Unlock()
+
+	return m.SendEvent(&entity.Event{
+		EventType:   entity.EventTypeConversion,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     conversion,
+	})
+}
+
+func (m *Memory) SendOrderPlaced(order *entity.Order) error {
+	return m.sendCDP(entity.EventTypeOrderPlaced, order)
+}
+
+func (m *Memory) SendOrderPlacedItem(item *entity.OrderItem) error {
+	return m.sendCDP(entity.EventTypeOrderPlacedItem, item)
+}
+
+func (m *Memory) SendCartAbandoned(cart *entity.Cart) error {
+	return m.sendCDP(entity.EventTypeCartAbandoned, cart)
+}
+
+func (m *Memory) SendCartAbandonedItem(item *entity.CartItem) error {
+	return m.sendCDP(entity.EventTypeCartAbandonedItem, item)
+}
+
+func (m *Memory) sendCDP(eventType string, payload interface{}) error {
+	return m.SendEvent(&entity.Event{
+		EventType:   eventType,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     payload,
+	})
+}
+
+// store saves a copy of lead, which must be called with the lock held.
+func (m *Memory) store(lead entity.Lead) entity.Lead {
+	if lead.Uuid == "" {
+		m.sequence++
+		lead.Uuid = uuid(m.sequence)
+	}
+	m.leads[strings.ToLower(lead.Email)] = clone(lead)
+
+	return clone(lead)
+}
+
+// patch returns stored with the non empty fields of changes applied.
+func patch(stored entity.Lead, changes *entity.Lead) entity.Lead {
+	patched := clone(stored)
+	data, err := json.Marshal(changes)
+	if err == nil {
+		err = json.Unmarshal(data, &patched)
+	}
+	if err != nil {
+		patched = clone(*changes)
+	}
+	patched.Uuid = stored.Uuid
+
+	return patched
+}
+
+func clone(lead entity.Lead) entity.Lead {
+	if lead.Tags != nil {
+		lead.Tags = append([]string{}, lead.Tags...)
+	}
+	if lead.ExtraEmails != nil {
+		lead.ExtraEmails = append([]string{}, lead.ExtraEmails...)
+	}
+	if lead.CustomFields != nil {
+		fields := make(map[string]interface{}, len(lead.CustomFields))
+		for name, value := range lead.CustomFields {
+			fields[name] = value
+		}
+		lead.CustomFields = fields
+	}
+
+	return lead
+}
+
+func contains(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func without(tags []string, tag string) []string {
+	kept := []string{}
+	for _, t := range tags {
+		if t != tag {
+			kept = append(kept, t)
+		}
+	}
+
+	return kept
+}
+
+var errLeadNotFound = apiError(http.StatusNotFound, "RESOURCE_NOT_FOUND", "Lead not found.")
+
+func apiError(status int, errorType, message string) error {
+	return rdstation.RDError{Errors: rdstation.Errors{StatusCode: status, Type: errorType, Message: message}}
+}
+
+func checkEmail(email string) error {
+	_, err := mail.ParseAddress(email)
+	if err != nil {
+		return apiError(http.StatusUnprocessableEntity, "INVALID_EMAIL", "Email is invalid.")
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package rdstationtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+// ErrNotImplemented is returned by the Memory methods that have no in-memory
+// version while Memory.RDStation is nil.
+var ErrNotImplemented = errors.New("rdstationtest: not implemented by Memory, set Memory.RDStation to stub it")
+
+func notImplemented(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotImplemented, method)
+}
+
+// notImplementedIterator fails on the first call to Next.
+func notImplementedIterator[T any](ctx context.Context, method string) *rdstation.Iterator[T] {
+	return rdstation.NewPageIterator(ctx, 1, func(ctx context.Context, page, pageSize int) ([]T, error) {
+		return nil, notImplemented(method)
+	})
+}
+
+func (m *Memory) AccountInfo() (*entity.AccountInfo, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("AccountInfo")
+	}
+
+	return m.RDStation.AccountInfo()
+}
+
+func (m *Memory) ListWebhooks() ([]entity.WebhookSubscription, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("ListWebhooks")
+	}
+
+	return m.RDStation.ListWebhooks()
+}
+
+func (m *Memory) CreateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("CreateWebhook")
+	}
+
+	return m.RDStation.CreateWebhook(webhook)
+}
+
+func (m *Memory) UpdateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("UpdateWebhook")
+	}
+
+	return m.RDStation.UpdateWebhook(webhook)
+}
+
+func (m *Memory) DeleteWebhook(uuid string) error {
+	if m.RDStation == nil {
+		return notImplemented("DeleteWebhook")
+	}
+
+	return m.RDStation.DeleteWebhook(uuid)
+}
+
+func (m *Memory) ListSegmentations() ([]entity.Segmentation, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("ListSegmentations")
+	}
+
+	return m.RDStation.ListSegmentations()
+}
+
+func (m *Memory) SegmentationContacts(ctx context.Context, segmentationID, pageSize int) *rdstation.Iterator[entity.Lead] {
+	if m.RDStation == nil {
+		return notImplementedIterator[entity.Lead](ctx, "SegmentationContacts")
+	}
+
+	return m.RDStation.SegmentationContacts(ctx, segmentationID, pageSize)
+}
+
+func (m *Memory) ConversionAnalytics(dates entity.DateRange, assetTypes ...string) (*entity.ConversionAnalytics, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("ConversionAnalytics")
+	}
+
+	return m.RDStation.ConversionAnalytics(dates, assetTypes...)
+}
+
+func (m *Memory) DailyConversionAnalytics(dates entity.DateRange, assetTypes ...string) ([]entity.ConversionPoint, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("DailyConversionAnalytics")
+	}
+
+	return m.RDStation.DailyConversionAnalytics(dates, assetTypes...)
+}
+
+func (m *Memory) EmailAnalytics(dates entity.DateRange) (*entity.EmailAnalytics, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("EmailAnalytics")
+	}
+
+	return m.RDStation.EmailAnalytics(dates)
+}
+
+func (m *Memory) FunnelAnalytics(dates entity.DateRange) (*entity.FunnelAnalytics, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("FunnelAnalytics")
+	}
+
+	return m.RDStation.FunnelAnalytics(dates)
+}
+
+func (m *Memory) ListEmails(ctx context.Context, filter entity.EmailFilter) *rdstation.Iterator[entity.Email] {
+	if m.RDStation == nil {
+		return notImplementedIterator[entity.Email](ctx, "ListEmails")
+	}
+
+	return m.RDStation.ListEmails(ctx, filter)
+}
+
+func (m *Memory) GetEmail(id int) (*entity.Email, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("GetEmail")
+	}
+
+	return m.RDStation.GetEmail(id)
+}
+
+func (m *Memory) ListLandingPages(ctx context.Context) *rdstation.Iterator[entity.LandingPage] {
+	if m.RDStation == nil {
+		return notImplementedIterator[entity.LandingPage](ctx, "ListLandingPages")
+	}
+
+	return m.RDStation.ListLandingPages(ctx)
+}
+
+func (m *Memory) ListPopups(ctx context.Context) *rdstation.Iterator[entity.Popup] {
+	if m.RDStation == nil {
+		return notImplementedIterator[entity.Popup](ctx, "ListPopups")
+	}
+
+	return m.RDStation.ListPopups(ctx)
+}
+
+func (m *Memory) ConversionIdentifiers(ctx context.Context) (map[string]string, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("ConversionIdentifiers")
+	}
+
+	return m.RDStation.ConversionIdentifiers(ctx)
+}
+
+func (m *Memory) ListWorkflows(ctx context.Context) *rdstation.Iterator[entity.Workflow] {
+	if m.RDStation == nil {
+		return notImplementedIterator[entity.Workflow](ctx, "ListWorkflows")
+	}
+
+	return m.RDStation.ListWorkflows(ctx)
+}
+
+func (m *Memory) EnrollInWorkflow(workflow *entity.Workflow, lead *entity.Lead, identifier string) error {
+	if m.RDStation == nil {
+		return notImplemented("EnrollInWorkflow")
+	}
+
+	return m.RDStation.EnrollInWorkflow(workflow, lead, identifier)
+}
+
+func (m *Memory) GetFunnel(email string) (*entity.Funnel, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("GetFunnel")
+	}
+
+	return m.RDStation.GetFunnel(email)
+}
+
+func (m *Memory) UpdateFunnel(email string, funnel *entity.Funnel) (*entity.Funnel, error) {
+	if m.RDStation == nil {
+		return nil, notImplemented("UpdateFunnel")
+	}
+
+	return m.RDStation.UpdateFunnel(email, funnel)
+}
@@ -0,0 +1,119 @@
+package rdstationtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+var (
+	_ rdstation.RDStation           = (*MockRDStation)(nil)
+	_ rdstation.WebhookService      = (*MockWebhookService)(nil)
+	_ rdstation.SegmentationService = (*MockSegmentationService)(nil)
+	_ rdstation.AnalyticsService    = (*MockAnalyticsService)(nil)
+	_ rdstation.EmailService        = (*MockEmailService)(nil)
+	_ rdstation.LandingPageService  = (*MockLandingPageService)(nil)
+	_ rdstation.WorkflowService     = (*MockWorkflowService)(nil)
+	_ rdstation.FunnelService       = (*MockFunnelService)(nil)
+)
+
+func TestMemory(t *testing.T) {
+	memory := NewMemory(entity.Lead{Email: "batata@example.com", Tags: []string{"cliente"}})
+
+	lead, err := memory.GetLeadByEmail("BATATA@example.com")
+	require.NoError(t, err)
+	assert.NotEmpty(t, lead.Uuid)
+
+	require.NoError(t, memory.AddTags(lead, []string{"cliente", "vip"}))
+	assert.Equal(t, []string{"cliente", "vip"}, lead.Tags)
+
+	require.NoError(t, memory.RemoveTags(lead, []string{"cliente"}))
+	stored, _ := memory.Lead("batata@example.com")
+	assert.Equal(t, []string{"vip"}, stored.Tags)
+
+	_, err = memory.CreateLead(&entity.Lead{Email: "batata@example.com"})
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode(t, err))
+
+	_, err = memory.GetLeadByUUID("nenhum")
+	assert.Equal(t, http.StatusNotFound, statusCode(t, err))
+
+	upserted, err := memory.UpsertLead(&entity.Lead{Email: "batata@example.com", Name: "Batata"})
+	require.NoError(t, err)
+	assert.Equal(t, lead.Uuid, upserted.Uuid)
+
+	require.NoError(t, memory.SendConversion(entity.NewConversion("cadastro", &entity.Lead{Email: "nova@example.com"})))
+	require.NoError(t, memory.SendOrderPlaced(&entity.Order{Email: "nova@example.com", OrderID: "1"}))
+	assert.Len(t, memory.Leads(), 2)
+	assert.Len(t, memory.Events(), 2)
+
+	require.NoError(t, memory.DeleteLeadByEmail("nova@example.com"))
+	assert.Equal(t, http.StatusNotFound, statusCode(t, memory.DeleteLeadByEmail("nova@example.com")))
+
+	t.Run("update keeps other fields", func(t *testing.T) {
+		memory := NewMemory(entity.Lead{
+			Name:         "Batata",
+			Email:        "batata@example.com",
+			Tags:         []string{"cliente"},
+			CustomFields: map[string]interface{}{"cf_plano": "ouro"},
+		})
+
+		require.NoError(t, memory.UpdateLead(&entity.Lead{
+			Email:        "batata@example.com",
+			City:         "Curitiba",
+			CustomFields: map[string]interface{}{"cf_origem": "site"},
+		}))
+
+		lead, _ := memory.Lead("batata@example.com")
+		assert.Equal(t, "Batata", lead.Name)
+		assert.Equal(t, "Curitiba", lead.City)
+		assert.Equal(t, []string{"cliente"}, lead.Tags)
+		assert.Equal(t, map[string]interface{}{"cf_plano": "ouro", "cf_origem": "site"}, lead.CustomFields)
+	})
+
+	t.Run("academy tags", func(t *testing.T) {
+		memory := NewMemory(entity.Lead{Email: "batata@example.com", Tags: []string{"cliente", entity.AcademyTagCancelled}})
+		lead, _ := memory.GetLeadByEmail("batata@example.com")
+
+		require.NoError(t, memory.AddTags(lead, []string{entity.AcademyActive}))
+		assert.Equal(t, []string{"cliente", entity.AcademyActive}, lead.Tags)
+
+		require.NoError(t, memory.AddTags(lead, []string{entity.AcademyTagCancelled}))
+		stored, _ := memory.Lead("batata@example.com")
+		assert.Equal(t, []string{"cliente", entity.AcademyTagCancelled}, stored.Tags)
+	})
+
+	t.Run("not implemented", func(t *testing.T) {
+		_, err := memory.ListWebhooks()
+		assert.ErrorIs(t, err, ErrNotImplemented)
+
+		err = memory.EnrollInWorkflow(&entity.Workflow{}, &entity.Lead{}, "")
+		assert.ErrorIs(t, err, ErrNotImplemented)
+
+		it := memory.ListWorkflows(context.Background())
+		assert.False(t, it.Next())
+		assert.ErrorIs(t, it.Err(), ErrNotImplemented)
+	})
+
+	t.Run("delegates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mock := NewMockRDStation(ctrl)
+		memory.RDStation = mock
+
+		mock.EXPECT().ListWebhooks().Return([]entity.WebhookSubscription{{UUID: "w1"}}, nil)
+		mock.EXPECT().ListWorkflows(gomock.Any()).Return(nil)
+
+		webhooks, err := memory.ListWebhooks()
+		require.NoError(t, err)
+		assert.Equal(t, "w1", webhooks[0].UUID)
+		assert.Nil(t, memory.ListWorkflows(context.Background()))
+	})
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: analytics.go
+
+// Package rdstationtest is a generated GoMock package.
+package rdstationtest
+
+import (
+	reflect "reflect"
+
+	entity "github.com/flan6/rdstation/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAnalyticsService is a mock of AnalyticsService interface.
+type MockAnalyticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsServiceMockRecorder
+}
+
+// MockAnalyticsServiceMockRecorder is the mock recorder for MockAnalyticsService.
+type MockAnalyticsServiceMockRecorder struct {
+	mock *MockAnalyticsService
+}
+
+// NewMockAnalyticsService creates a new mock instance.
+func NewMockAnalyticsService(ctrl *gomock.Controller) *MockAnalyticsService {
+	mock := &MockAnalyticsService{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsService) EXPECT() *MockAnalyticsServiceMockRecorder {
+	return m.recorder
+}
+
+// ConversionAnalytics mocks base method.
+func (m *MockAnalyticsService) ConversionAnalytics(dates entity.DateRange, assetTypes ...string) (*entity.ConversionAnalytics, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{dates}
+	for _, a := range assetTypes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConversionAnalytics", varargs...)
+	ret0, _ := ret[0].(*entity.ConversionAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConversionAnalytics indicates an expected call of ConversionAnalytics.
+func (mr *MockAnalyticsServiceMockRecorder) ConversionAnalytics(dates interface{}, assetTypes ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{dates}, assetTypes...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConversionAnalytics", reflect.TypeOf((*MockAnalyticsService)(nil).ConversionAnalytics), varargs...)
+}
+
+// DailyConversionAnalytics mocks base method.
+func (m *MockAnalyticsService) DailyConversionAnalytics(dates entity.DateRange, assetTypes ...string) ([]entity.ConversionPoint, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{dates}
+	for _, a := range assetTypes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DailyConversionAnalytics", varargs...)
+	ret0, _ := ret[0].([]entity.ConversionPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DailyConversionAnalytics indicates an expected call of DailyConversionAnalytics.
+func (mr *MockAnalyticsServiceMockRecorder) DailyConversionAnalytics(dates interface{}, assetTypes ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{dates}, assetTypes...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DailyConversionAnalytics", reflect.TypeOf((*MockAnalyticsService)(nil).DailyConversionAnalytics), varargs...)
+}
+
+// EmailAnalytics mocks base method.
+func (m *MockAnalyticsService) EmailAnalytics(dates entity.DateRange) (*entity.EmailAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailAnalytics", dates)
+	ret0, _ := ret[0].(*entity.EmailAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmailAnalytics indicates an expected call of EmailAnalytics.
+func (mr *MockAnalyticsServiceMockRecorder) EmailAnalytics(dates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailAnalytics", reflect.TypeOf((*MockAnalyticsService)(nil).EmailAnalytics), dates)
+}
+
+// FunnelAnalytics mocks base method.
+func (m *MockAnalyticsService) FunnelAnalytics(dates entity.DateRange) (*entity.FunnelAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FunnelAnalytics", dates)
+	ret0, _ := ret[0].(*entity.FunnelAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FunnelAnalytics indicates an expected call of FunnelAnalytics.
+func (mr *MockAnalyticsServiceMockRecorder) FunnelAnalytics(dates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FunnelAnalytics", reflect.TypeOf((*MockAnalyticsService)(nil).FunnelAnalytics), dates)
+}
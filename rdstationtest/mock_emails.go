@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: emails.go
+
+// Package rdstationtest is a generated GoMock package.
+package rdstationtest
+
+import (
+	context "context"
+	reflect "reflect"
+
+	rdstation "github.com/flan6/rdstation"
+	entity "github.com/flan6/rdstation/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailService is a mock of EmailService interface.
+type MockEmailService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailServiceMockRecorder
+}
+
+// MockEmailServiceMockRecorder is the mock recorder for MockEmailService.
+type MockEmailServiceMockRecorder struct {
+	mock *MockEmailService
+}
+
+// NewMockEmailService creates a new mock instance.
+func NewMockEmailService(ctrl *gomock.Controller) *MockEmailService {
+	mock := &MockEmailService{ctrl: ctrl}
+	mock.recorder = &MockEmailServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailService) EXPECT() *MockEmailServiceMockRecorder {
+	return m.recorder
+}
+
+// GetEmail mocks base method.
+func (m *MockEmailService) GetEmail(id int) (*entity.Email, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmail", id)
+	ret0, _ := ret[0].(*entity.Email)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmail indicates an expected call of GetEmail.
+func (mr *MockEmailServiceMockRecorder) GetEmail(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmail", reflect.TypeOf((*MockEmailService)(nil).GetEmail), id)
+}
+
+// ListEmails mocks base method.
+func (m *MockEmailService) ListEmails(ctx context.Context, filter entity.EmailFilter) *rdstation.Iterator[entity.Email] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmails", ctx, filter)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Email])
+	return ret0
+}
+
+// ListEmails indicates an expected call of ListEmails.
+func (mr *MockEmailServiceMockRecorder) ListEmails(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmails", reflect.TypeOf((*MockEmailService)(nil).ListEmails), ctx, filter)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: funnels.go
+
+// Package rdstationtest is a generated GoMock package.
+package rdstationtest
+
+import (
+	reflect "reflect"
+
+	entity "github.com/flan6/rdstation/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFunnelService is a mock of FunnelService interface.
+type MockFunnelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFunnelServiceMockRecorder
+}
+
+// MockFunnelServiceMockRecorder is the mock recorder for MockFunnelService.
+type MockFunnelServiceMockRecorder struct {
+	mock *MockFunnelService
+}
+
+// NewMockFunnelService creates a new mock instance.
+func NewMockFunnelService(ctrl *gomock.Controller) *MockFunnelService {
+	mock := &MockFunnelService{ctrl: ctrl}
+	mock.recorder = &MockFunnelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFunnelService) EXPECT() *MockFunnelServiceMockRecorder {
+	return m.recorder
+}
+
+// GetFunnel mocks base method.
+func (m *MockFunnelService) GetFunnel(email string) (*entity.Funnel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunnel", email)
+	ret0, _ := ret[0].(*entity.Funnel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunnel indicates an expected call of GetFunnel.
+func (mr *MockFunnelServiceMockRecorder) GetFunnel(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunnel", reflect.TypeOf((*MockFunnelService)(nil).GetFunnel), email)
+}
+
+// UpdateFunnel mocks base method.
+func (m *MockFunnelService) UpdateFunnel(email string, funnel *entity.Funnel) (*entity.Funnel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunnel", email, funnel)
+	ret0, _ := ret[0].(*entity.Funnel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunnel indicates an expected call of UpdateFunnel.
+func (mr *MockFunnelServiceMockRecorder) UpdateFunnel(email, funnel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunnel", reflect.TypeOf((*MockFunnelService)(nil).UpdateFunnel), email, funnel)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: landing_pages.go
+
+// Package rdstationtest is a generated GoMock package.
+package rdstationtest
+
+import (
+	context "context"
+	reflect "reflect"
+
+	rdstation "github.com/flan6/rdstation"
+	entity "github.com/flan6/rdstation/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLandingPageService is a mock of LandingPageService interface.
+type MockLandingPageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLandingPageServiceMockRecorder
+}
+
+// MockLandingPageServiceMockRecorder is the mock recorder for MockLandingPageService.
+type MockLandingPageServiceMockRecorder struct {
+	mock *MockLandingPageService
+}
+
+// NewMockLandingPageService creates a new mock instance.
+func NewMockLandingPageService(ctrl *gomock.Controller) *MockLandingPageService {
+	mock := &MockLandingPageService{ctrl: ctrl}
+	mock.recorder = &MockLandingPageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLandingPageService) EXPECT() *MockLandingPageServiceMockRecorder {
+	return m.recorder
+}
+
+// ConversionIdentifiers mocks base method.
+func (m *MockLandingPageService) ConversionIdentifiers(ctx context.Context) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConversionIdentifiers", ctx)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConversionIdentifiers indicates an expected call of ConversionIdentifiers.
+func (mr *MockLandingPageServiceMockRecorder) ConversionIdentifiers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConversionIdentifiers", reflect.TypeOf((*MockLandingPageService)(nil).ConversionIdentifiers), ctx)
+}
+
+// ListLandingPages mocks base method.
+func (m *MockLandingPageService) ListLandingPages(ctx context.Context) *rdstation.Iterator[entity.LandingPage] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLandingPages", ctx)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.LandingPage])
+	return ret0
+}
+
+// ListLandingPages indicates an expected call of ListLandingPages.
+func (mr *MockLandingPageServiceMockRecorder) ListLandingPages(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLandingPages", reflect.TypeOf((*MockLandingPageService)(nil).ListLandingPages), ctx)
+}
+
+// ListPopups mocks base method.
+func (m *MockLandingPageService) ListPopups(ctx context.Context) *rdstation.Iterator[entity.Popup] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPopups", ctx)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Popup])
+	return ret0
+}
+
+// ListPopups indicates an expected call of ListPopups.
+func (mr *MockLandingPageServiceMockRecorder) ListPopups(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPopups", reflect.TypeOf((*MockLandingPageService)(nil).ListPopups), ctx)
+}
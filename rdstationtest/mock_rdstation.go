@@ -0,0 +1,554 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rdstation.go
+
+// Package rdstationtest is a generated GoMock package.
+package rdstationtest
+
+import (
+	context "context"
+	reflect "reflect"
+
+	rdstation "github.com/flan6/rdstation"
+	entity "github.com/flan6/rdstation/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRDStation is a mock of RDStation interface.
+type MockRDStation struct {
+	ctrl     *gomock.Controller
+	recorder *MockRDStationMockRecorder
+}
+
+// MockRDStationMockRecorder is the mock recorder for MockRDStation.
+type MockRDStationMockRecorder struct {
+	mock *MockRDStation
+}
+
+// NewMockRDStation creates a new mock instance.
+func NewMockRDStation(ctrl *gomock.Controller) *MockRDStation {
+	mock := &MockRDStation{ctrl: ctrl}
+	mock.recorder = &MockRDStationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRDStation) EXPECT() *MockRDStationMockRecorder {
+	return m.recorder
+}
+
+// AccountInfo mocks base method.
+func (m *MockRDStation) AccountInfo() (*entity.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountInfo")
+	ret0, _ := ret[0].(*entity.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountInfo indicates an expected call of AccountInfo.
+func (mr *MockRDStationMockRecorder) AccountInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountInfo", reflect.TypeOf((*MockRDStation)(nil).AccountInfo))
+}
+
+// AddTags mocks base method.
+func (m *MockRDStation) AddTags(lead *entity.Lead, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", lead, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockRDStationMockRecorder) AddTags(lead, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockRDStation)(nil).AddTags), lead, tags)
+}
+
+// ConversionAnalytics mocks base method.
+func (m *MockRDStation) ConversionAnalytics(dates entity.DateRange, assetTypes ...string) (*entity.ConversionAnalytics, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{dates}
+	for _, a := range assetTypes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConversionAnalytics", varargs...)
+	ret0, _ := ret[0].(*entity.ConversionAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConversionAnalytics indicates an expected call of ConversionAnalytics.
+func (mr *MockRDStationMockRecorder) ConversionAnalytics(dates interface{}, assetTypes ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{dates}, assetTypes...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConversionAnalytics", reflect.TypeOf((*MockRDStation)(nil).ConversionAnalytics), varargs...)
+}
+
+// ConversionIdentifiers mocks base method.
+func (m *MockRDStation) ConversionIdentifiers(ctx context.Context) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConversionIdentifiers", ctx)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConversionIdentifiers indicates an expected call of ConversionIdentifiers.
+func (mr *MockRDStationMockRecorder) ConversionIdentifiers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConversionIdentifiers", reflect.TypeOf((*MockRDStation)(nil).ConversionIdentifiers), ctx)
+}
+
+// CreateLead mocks base method.
+func (m *MockRDStation) CreateLead(lead *entity.Lead) (*entity.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLead", lead)
+	ret0, _ := ret[0].(*entity.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLead indicates an expected call of CreateLead.
+func (mr *MockRDStationMockRecorder) CreateLead(lead interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLead", reflect.TypeOf((*MockRDStation)(nil).CreateLead), lead)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockRDStation) CreateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", webhook)
+	ret0, _ := ret[0].(*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockRDStationMockRecorder) CreateWebhook(webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockRDStation)(nil).CreateWebhook), webhook)
+}
+
+// DailyConversionAnalytics mocks base method.
+func (m *MockRDStation) DailyConversionAnalytics(dates entity.DateRange, assetTypes ...string) ([]entity.ConversionPoint, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{dates}
+	for _, a := range assetTypes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DailyConversionAnalytics", varargs...)
+	ret0, _ := ret[0].([]entity.ConversionPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DailyConversionAnalytics indicates an expected call of DailyConversionAnalytics.
+func (mr *MockRDStationMockRecorder) DailyConversionAnalytics(dates interface{}, assetTypes ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{dates}, assetTypes...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DailyConversionAnalytics", reflect.TypeOf((*MockRDStation)(nil).DailyConversionAnalytics), varargs...)
+}
+
+// DeleteLeadByEmail mocks base method.
+func (m *MockRDStation) DeleteLeadByEmail(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLeadByEmail", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLeadByEmail indicates an expected call of DeleteLeadByEmail.
+func (mr *MockRDStationMockRecorder) DeleteLeadByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLeadByEmail", reflect.TypeOf((*MockRDStation)(nil).DeleteLeadByEmail), email)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockRDStation) DeleteWebhook(uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockRDStationMockRecorder) DeleteWebhook(uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockRDStation)(nil).DeleteWebhook), uuid)
+}
+
+// EmailAnalytics mocks base method.
+func (m *MockRDStation) EmailAnalytics(dates entity.DateRange) (*entity.EmailAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailAnalytics", dates)
+	ret0, _ := ret[0].(*entity.EmailAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmailAnalytics indicates an expected call of EmailAnalytics.
+func (mr *MockRDStationMockRecorder) EmailAnalytics(dates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailAnalytics", reflect.TypeOf((*MockRDStation)(nil).EmailAnalytics), dates)
+}
+
+// EnrollInWorkflow mocks base method.
+func (m *MockRDStation) EnrollInWorkflow(workflow *entity.Workflow, lead *entity.Lead, identifier string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollInWorkflow", workflow, lead, identifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollInWorkflow indicates an expected call of EnrollInWorkflow.
+func (mr *MockRDStationMockRecorder) EnrollInWorkflow(workflow, lead, identifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollInWorkflow", reflect.TypeOf((*MockRDStation)(nil).EnrollInWorkflow), workflow, lead, identifier)
+}
+
+// FunnelAnalytics mocks base method.
+func (m *MockRDStation) FunnelAnalytics(dates entity.DateRange) (*entity.FunnelAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FunnelAnalytics", dates)
+	ret0, _ := ret[0].(*entity.FunnelAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FunnelAnalytics indicates an expected call of FunnelAnalytics.
+func (mr *MockRDStationMockRecorder) FunnelAnalytics(dates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FunnelAnalytics", reflect.TypeOf((*MockRDStation)(nil).FunnelAnalytics), dates)
+}
+
+// GetEmail mocks base method.
+func (m *MockRDStation) GetEmail(id int) (*entity.Email, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmail", id)
+	ret0, _ := ret[0].(*entity.Email)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmail indicates an expected call of GetEmail.
+func (mr *MockRDStationMockRecorder) GetEmail(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmail", reflect.TypeOf((*MockRDStation)(nil).GetEmail), id)
+}
+
+// GetFunnel mocks base method.
+func (m *MockRDStation) GetFunnel(email string) (*entity.Funnel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunnel", email)
+	ret0, _ := ret[0].(*entity.Funnel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunnel indicates an expected call of GetFunnel.
+func (mr *MockRDStationMockRecorder) GetFunnel(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunnel", reflect.TypeOf((*MockRDStation)(nil).GetFunnel), email)
+}
+
+// GetLeadByEmail mocks base method.
+func (m *MockRDStation) GetLeadByEmail(email string) (*entity.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeadByEmail", email)
+	ret0, _ := ret[0].(*entity.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeadByEmail indicates an expected call of GetLeadByEmail.
+func (mr *MockRDStationMockRecorder) GetLeadByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeadByEmail", reflect.TypeOf((*MockRDStation)(nil).GetLeadByEmail), email)
+}
+
+// GetLeadByUUID mocks base method.
+func (m *MockRDStation) GetLeadByUUID(uuid string) (*entity.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeadByUUID", uuid)
+	ret0, _ := ret[0].(*entity.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeadByUUID indicates an expected call of GetLeadByUUID.
+func (mr *MockRDStationMockRecorder) GetLeadByUUID(uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeadByUUID", reflect.TypeOf((*MockRDStation)(nil).GetLeadByUUID), uuid)
+}
+
+// ListEmails mocks base method.
+func (m *MockRDStation) ListEmails(ctx context.Context, filter entity.EmailFilter) *rdstation.Iterator[entity.Email] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmails", ctx, filter)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Email])
+	return ret0
+}
+
+// ListEmails indicates an expected call of ListEmails.
+func (mr *MockRDStationMockRecorder) ListEmails(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmails", reflect.TypeOf((*MockRDStation)(nil).ListEmails), ctx, filter)
+}
+
+// ListLandingPages mocks base method.
+func (m *MockRDStation) ListLandingPages(ctx context.Context) *rdstation.Iterator[entity.LandingPage] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLandingPages", ctx)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.LandingPage])
+	return ret0
+}
+
+// ListLandingPages indicates an expected call of ListLandingPages.
+func (mr *MockRDStationMockRecorder) ListLandingPages(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLandingPages", reflect.TypeOf((*MockRDStation)(nil).ListLandingPages), ctx)
+}
+
+// ListPopups mocks base method.
+func (m *MockRDStation) ListPopups(ctx context.Context) *rdstation.Iterator[entity.Popup] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPopups", ctx)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Popup])
+	return ret0
+}
+
+// ListPopups indicates an expected call of ListPopups.
+func (mr *MockRDStationMockRecorder) ListPopups(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPopups", reflect.TypeOf((*MockRDStation)(nil).ListPopups), ctx)
+}
+
+// ListSegmentations mocks base method.
+func (m *MockRDStation) ListSegmentations() ([]entity.Segmentation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSegmentations")
+	ret0, _ := ret[0].([]entity.Segmentation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSegmentations indicates an expected call of ListSegmentations.
+func (mr *MockRDStationMockRecorder) ListSegmentations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSegmentations", reflect.TypeOf((*MockRDStation)(nil).ListSegmentations))
+}
+
+// ListWebhooks mocks base method.
+func (m *MockRDStation) ListWebhooks() ([]entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks")
+	ret0, _ := ret[0].([]entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockRDStationMockRecorder) ListWebhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockRDStation)(nil).ListWebhooks))
+}
+
+// ListWorkflows mocks base method.
+func (m *MockRDStation) ListWorkflows(ctx context.Context) *rdstation.Iterator[entity.Workflow] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkflows", ctx)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Workflow])
+	return ret0
+}
+
+// ListWorkflows indicates an expected call of ListWorkflows.
+func (mr *MockRDStationMockRecorder) ListWorkflows(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkflows", reflect.TypeOf((*MockRDStation)(nil).ListWorkflows), ctx)
+}
+
+// RemoveTags mocks base method.
+func (m *MockRDStation) RemoveTags(lead *entity.Lead, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTags", lead, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTags indicates an expected call of RemoveTags.
+func (mr *MockRDStationMockRecorder) RemoveTags(lead, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockRDStation)(nil).RemoveTags), lead, tags)
+}
+
+// SegmentationContacts mocks base method.
+func (m *MockRDStation) SegmentationContacts(ctx context.Context, segmentationID, pageSize int) *rdstation.Iterator[entity.Lead] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SegmentationContacts", ctx, segmentationID, pageSize)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Lead])
+	return ret0
+}
+
+// SegmentationContacts indicates an expected call of SegmentationContacts.
+func (mr *MockRDStationMockRecorder) SegmentationContacts(ctx, segmentationID, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SegmentationContacts", reflect.TypeOf((*MockRDStation)(nil).SegmentationContacts), ctx, segmentationID, pageSize)
+}
+
+// SendCartAbandoned mocks base method.
+func (m *MockRDStation) SendCartAbandoned(cart *entity.Cart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCartAbandoned", cart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCartAbandoned indicates an expected call of SendCartAbandoned.
+func (mr *MockRDStationMockRecorder) SendCartAbandoned(cart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCartAbandoned", reflect.TypeOf((*MockRDStation)(nil).SendCartAbandoned), cart)
+}
+
+// SendCartAbandonedItem mocks base method.
+func (m *MockRDStation) SendCartAbandonedItem(item *entity.CartItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCartAbandonedItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCartAbandonedItem indicates an expected call of SendCartAbandonedItem.
+func (mr *MockRDStationMockRecorder) SendCartAbandonedItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCartAbandonedItem", reflect.TypeOf((*MockRDStation)(nil).SendCartAbandonedItem), item)
+}
+
+// SendConversion mocks base method.
+func (m *MockRDStation) SendConversion(conversion *entity.Conversion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendConversion", conversion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendConversion indicates an expected call of SendConversion.
+func (mr *MockRDStationMockRecorder) SendConversion(conversion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendConversion", reflect.TypeOf((*MockRDStation)(nil).SendConversion), conversion)
+}
+
+// SendEvent mocks base method.
+func (m *MockRDStation) SendEvent(event *entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEvent indicates an expected call of SendEvent.
+func (mr *MockRDStationMockRecorder) SendEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEvent", reflect.TypeOf((*MockRDStation)(nil).SendEvent), event)
+}
+
+// SendEventsBatch mocks base method.
+func (m *MockRDStation) SendEventsBatch(events []entity.Event) []rdstation.EventResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEventsBatch", events)
+	ret0, _ := ret[0].([]rdstation.EventResult)
+	return ret0
+}
+
+// SendEventsBatch indicates an expected call of SendEventsBatch.
+func (mr *MockRDStationMockRecorder) SendEventsBatch(events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEventsBatch", reflect.TypeOf((*MockRDStation)(nil).SendEventsBatch), events)
+}
+
+// SendOrderPlaced mocks base method.
+func (m *MockRDStation) SendOrderPlaced(order *entity.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendOrderPlaced", order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendOrderPlaced indicates an expected call of SendOrderPlaced.
+func (mr *MockRDStationMockRecorder) SendOrderPlaced(order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderPlaced", reflect.TypeOf((*MockRDStation)(nil).SendOrderPlaced), order)
+}
+
+// SendOrderPlacedItem mocks base method.
+func (m *MockRDStation) SendOrderPlacedItem(item *entity.OrderItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendOrderPlacedItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendOrderPlacedItem indicates an expected call of SendOrderPlacedItem.
+func (mr *MockRDStationMockRecorder) SendOrderPlacedItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderPlacedItem", reflect.TypeOf((*MockRDStation)(nil).SendOrderPlacedItem), item)
+}
+
+// UpdateFunnel mocks base method.
+func (m *MockRDStation) UpdateFunnel(email string, funnel *entity.Funnel) (*entity.Funnel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunnel", email, funnel)
+	ret0, _ := ret[0].(*entity.Funnel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunnel indicates an expected call of UpdateFunnel.
+func (mr *MockRDStationMockRecorder) UpdateFunnel(email, funnel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunnel", reflect.TypeOf((*MockRDStation)(nil).UpdateFunnel), email, funnel)
+}
+
+// UpdateLead mocks base method.
+func (m *MockRDStation) UpdateLead(leads *entity.Lead) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLead", leads)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLead indicates an expected call of UpdateLead.
+func (mr *MockRDStationMockRecorder) UpdateLead(leads interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLead", reflect.TypeOf((*MockRDStation)(nil).UpdateLead), leads)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockRDStation) UpdateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", webhook)
+	ret0, _ := ret[0].(*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockRDStationMockRecorder) UpdateWebhook(webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockRDStation)(nil).UpdateWebhook), webhook)
+}
+
+// UpsertLead mocks base method.
+func (m *MockRDStation) UpsertLead(lead *entity.Lead) (*entity.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLead", lead)
+	ret0, _ := ret[0].(*entity.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertLead indicates an expected call of UpsertLead.
+func (mr *MockRDStationMockRecorder) UpsertLead(lead interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLead", reflect.TypeOf((*MockRDStation)(nil).UpsertLead), lead)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: segmentations.go
+
+// Package rdstationtest is a generated GoMock package.
+package rdstationtest
+
+import (
+	context "context"
+	reflect "reflect"
+
+	rdstation "github.com/flan6/rdstation"
+	entity "github.com/flan6/rdstation/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSegmentationService is a mock of SegmentationService interface.
+type MockSegmentationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSegmentationServiceMockRecorder
+}
+
+// MockSegmentationServiceMockRecorder is the mock recorder for MockSegmentationService.
+type MockSegmentationServiceMockRecorder struct {
+	mock *MockSegmentationService
+}
+
+// NewMockSegmentationService creates a new mock instance.
+func NewMockSegmentationService(ctrl *gomock.Controller) *MockSegmentationService {
+	mock := &MockSegmentationService{ctrl: ctrl}
+	mock.recorder = &MockSegmentationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSegmentationService) EXPECT() *MockSegmentationServiceMockRecorder {
+	return m.recorder
+}
+
+// ListSegmentations mocks base method.
+func (m *MockSegmentationService) ListSegmentations() ([]entity.Segmentation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSegmentations")
+	ret0, _ := ret[0].([]entity.Segmentation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSegmentations indicates an expected call of ListSegmentations.
+func (mr *MockSegmentationServiceMockRecorder) ListSegmentations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSegmentations", reflect.TypeOf((*MockSegmentationService)(nil).ListSegmentations))
+}
+
+// SegmentationContacts mocks base method.
+func (m *MockSegmentationService) SegmentationContacts(ctx context.Context, segmentationID, pageSize int) *rdstation.Iterator[entity.Lead] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SegmentationContacts", ctx, segmentationID, pageSize)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Lead])
+	return ret0
+}
+
+// SegmentationContacts indicates an expected call of SegmentationContacts.
+func (mr *MockSegmentationServiceMockRecorder) SegmentationContacts(ctx, segmentationID, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SegmentationContacts", reflect.TypeOf((*MockSegmentationService)(nil).SegmentationContacts), ctx, segmentationID, pageSize)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workflows.go
+
+// Package rdstationtest is a generated GoMock package.
+package rdstationtest
+
+import (
+	context "context"
+	reflect "reflect"
+
+	rdstation "github.com/flan6/rdstation"
+	entity "github.com/flan6/rdstation/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorkflowService is a mock of WorkflowService interface.
+type MockWorkflowService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkflowServiceMockRecorder
+}
+
+// MockWorkflowServiceMockRecorder is the mock recorder for MockWorkflowService.
+type MockWorkflowServiceMockRecorder struct {
+	mock *MockWorkflowService
+}
+
+// NewMockWorkflowService creates a new mock instance.
+func NewMockWorkflowService(ctrl *gomock.Controller) *MockWorkflowService {
+	mock := &MockWorkflowService{ctrl: ctrl}
+	mock.recorder = &MockWorkflowServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkflowService) EXPECT() *MockWorkflowServiceMockRecorder {
+	return m.recorder
+}
+
+// EnrollInWorkflow mocks base method.
+func (m *MockWorkflowService) EnrollInWorkflow(workflow *entity.Workflow, lead *entity.Lead, identifier string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollInWorkflow", workflow, lead, identifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollInWorkflow indicates an expected call of EnrollInWorkflow.
+func (mr *MockWorkflowServiceMockRecorder) EnrollInWorkflow(workflow, lead, identifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollInWorkflow", reflect.TypeOf((*MockWorkflowService)(nil).EnrollInWorkflow), workflow, lead, identifier)
+}
+
+// ListWorkflows mocks base method.
+func (m *MockWorkflowService) ListWorkflows(ctx context.Context) *rdstation.Iterator[entity.Workflow] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkflows", ctx)
+	ret0, _ := ret[0].(*rdstation.Iterator[entity.Workflow])
+	return ret0
+}
+
+// ListWorkflows indicates an expected call of ListWorkflows.
+func (mr *MockWorkflowServiceMockRecorder) ListWorkflows(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkflows", reflect.TypeOf((*MockWorkflowService)(nil).ListWorkflows), ctx)
+}
@@ -0,0 +1,284 @@
+// Package rdstationtest provides an in-memory RD Station Marketing api for
+// tests that should exercise the real client, its json and its errors
+// without reaching the network.
+//
+//	server := rdstationtest.NewServer()
+//	defer server.Close()
+//
+//	rd, err := server.New()
+package rdstationtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+// Credentials accepted by a server built without WithSecret.
+const (
+	ClientID     = "rdstationtest-client"
+	ClientSecret = "rdstationtest-secret"
+	RefreshToken = "rdstationtest-refresh"
+)
+
+// Host is the api host requests are redirected from by Server.Client.
+const Host = "api.rd.services"
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	secret   entity.Secret
+	tokenTTL time.Duration
+	tokens   map[string]time.Time
+	leads    map[string]*entity.Lead
+	events   []entity.Event
+	account  entity.AccountInfo
+	sequence int
+	faults   []*Fault
+
+	limit   int
+	per     time.Duration
+	window  time.Time
+	current int
+}
+
+type Option func(*Server)
+
+// WithSecret changes the credentials the token exchange accepts.
+func WithSecret(secret entity.Secret) Option {
+	return func(s *Server) {
+		s.secret = secret
+	}
+}
+
+// WithTokenTTL changes how long access tokens last, one day by default. The
+// oauth2 client refreshes tokens about to expire, so a short ttl makes every
+// request go through a refresh.
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.tokenTTL = ttl
+	}
+}
+
+// WithRateLimit answers 429 once more than requests calls arrive in a period,
+// as RD Station does when an account exceeds its plan.
+func WithRateLimit(requests int, per time.Duration) Option {
+	return func(s *Server) {
+		s.limit = requests
+		s.per = per
+	}
+}
+
+// WithAccount sets the account returned by the account info endpoint.
+func WithAccount(account entity.AccountInfo) Option {
+	return func(s *Server) {
+		s.account = account
+	}
+}
+
+// NewServer starts a server, stop it with Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		secret: entity.Secret{
+			ClientID:     ClientID,
+			ClientSecret: ClientSecret,
+			RefreshToken: RefreshToken,
+		},
+		tokenTTL: 24 * time.Hour,
+		tokens:   map[string]time.Time{},
+		leads:    map[string]*entity.Lead{},
+		account:  entity.AccountInfo{ID: "1", Name: "rdstationtest"},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.route))
+
+	return s
+}
+
+// Client returns an http client that sends the requests meant for the RD
+// Station api to the server, so the library works unchanged.
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.URL)
+
+	return &http.Client{Transport: redirect{
+		target: target,
+		base:   s.Server.Client().Transport,
+	}}
+}
+
+// New returns a client authenticated against the server.
+func (s *Server) New(opts ...rdstation.Option) (rdstation.RDStation, error) {
+	opts = append([]rdstation.Option{rdstation.WithHTTPClient(s.Client())}, opts...)
+
+	return rdstation.New(s.secret.ClientID, s.secret.ClientSecret, s.secret.RefreshToken, opts...)
+}
+
+type redirect struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t redirect) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.URL.Host == Host {
+		r = r.Clone(r.Context())
+		r.URL.Scheme = t.target.Scheme
+		r.URL.Host = t.target.Host
+		r.Host = ""
+	}
+
+	return t.base.RoundTrip(r)
+}
+
+// ExpireTokens invalidates every access token issued so far, the clients get
+// 401 until they exchange their refresh token again.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]time.Time{}
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	if fault := s.fault(r, path); fault != nil && fault.inject(w, r) {
+		return
+	}
+
+	if path == "auth/token" {
+		s.token(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid token.")
+		return
+	}
+
+	if wait, limited := s.limited(); limited {
+		w.Header().Set("Retry-After", fmt.Sprint(int(wait.Seconds()+0.5)))
+		writeError(w, http.StatusTooManyRequests, "TOO_MANY_REQUESTS", "API rate limit exceeded.")
+		return
+	}
+
+	switch {
+	case path == rdstation.RDAccountInfoPath && r.Method == http.MethodGet:
+		s.mu.Lock()
+		account := s.account
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, account)
+	case path == strings.Trim(rdstation.RDLeadPath, "/"):
+		s.contacts(w, r)
+	case strings.HasPrefix(path, rdstation.RDLeadPath):
+		s.contact(w, r, strings.TrimPrefix(path, rdstation.RDLeadPath))
+	case path == rdstation.RDEventsPath && r.Method == http.MethodPost:
+		s.event(w, r)
+	case path == rdstation.RDBatchPath && r.Method == http.MethodPost:
+		s.batch(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Resource not found.")
+	}
+}
+
+// token serves both the initial exchange, sent as json, and the oauth2
+// refreshes, sent as a form.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	var secret entity.Secret
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		err := json.NewDecoder(r.Body).Decode(&secret)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+	} else {
+		secret = entity.Secret{
+			ClientID:     r.FormValue("client_id"),
+			ClientSecret: r.FormValue("client_secret"),
+			RefreshToken: r.FormValue("refresh_token"),
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if secret != s.secret {
+		writeError(w, http.StatusUnauthorized, "ACCESS_DENIED", "Wrong credentials provided.")
+		return
+	}
+
+	s.sequence++
+	token := fmt.Sprintf("rdstationtest-token-%d", s.sequence)
+	s.tokens[token] = time.Now().Add(s.tokenTTL)
+
+	writeJSON(w, http.StatusOK, entity.Token{
+		AccessToken:  token,
+		ExpiresIn:    int(s.tokenTTL.Seconds()),
+		RefreshToken: s.secret.RefreshToken,
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.tokens[token]
+
+	return ok && time.Now().Before(expiry)
+}
+
+func (s *Server) limited() (time.Duration, bool) {
+	if s.limit <= 0 {
+		return 0, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.window) >= s.per {
+		s.window = now
+		s.current = 0
+	}
+
+	s.current++
+
+	return s.per - now.Sub(s.window), s.current > s.limit
+}
+
+func (s *Server) newUUID() string {
+	s.sequence++
+	return uuid(s.sequence)
+}
+
+// uuid formats a sequence number as a uuid, distinct and easy to read back.
+func uuid(n int) string {
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", n, n)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type errorFields struct {
+	Type    string `json:"error_type"`
+	Message string `json:"error_message"`
+}
+
+func writeError(w http.ResponseWriter, status int, errorType, message string) {
+	writeJSON(w, status, map[string]errorFields{"errors": {Type: errorType, Message: message}})
+}
@@ -0,0 +1,154 @@
+package rdstationtest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+func statusCode(t *testing.T, err error) int {
+	t.Helper()
+
+	var rdErr rdstation.RDError
+	require.True(t, errors.As(err, &rdErr), "%v", err)
+
+	return rdErr.Errors.StatusCode
+}
+
+func TestServer_Contacts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	rd, err := server.New()
+	require.NoError(t, err)
+
+	created, err := rd.CreateLead(&entity.Lead{
+		Name:         "Batata",
+		Email:        "batata@example.com",
+		CustomFields: map[string]interface{}{"cf_plano": "ouro"},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Uuid)
+
+	_, err = rd.CreateLead(&entity.Lead{Email: "batata@example.com"})
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode(t, err))
+
+	_, err = rd.CreateLead(&entity.Lead{Email: "batata"})
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode(t, err))
+
+	lead, err := rd.GetLeadByUUID(created.Uuid)
+	require.NoError(t, err)
+	assert.Equal(t, "ouro", lead.CustomFields["cf_plano"])
+
+	require.NoError(t, rd.UpdateLead(&entity.Lead{Email: "batata@example.com", City: "Curitiba"}))
+	require.NoError(t, rd.AddTags(lead, []string{"cliente"}))
+
+	lead, err = rd.GetLeadByEmail("batata@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Batata", lead.Name)
+	assert.Equal(t, "Curitiba", lead.City)
+	assert.Equal(t, []string{"cliente"}, lead.Tags)
+
+	require.NoError(t, rd.RemoveTags(lead, []string{"cliente"}))
+	stored, _ := server.Lead("batata@example.com")
+	assert.Empty(t, stored.Tags)
+
+	upserted, err := rd.UpsertLead(&entity.Lead{Email: "nova@example.com", Name: "Nova"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, upserted.Uuid)
+	assert.Len(t, server.Leads(), 2)
+
+	require.NoError(t, rd.DeleteLeadByEmail("batata@example.com"))
+	_, err = rd.GetLeadByEmail("batata@example.com")
+	assert.Equal(t, http.StatusNotFound, statusCode(t, err))
+	assert.Equal(t, http.StatusNotFound, statusCode(t, rd.DeleteLeadByEmail("batata@example.com")))
+}
+
+func TestServer_Events(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	rd, err := server.New()
+	require.NoError(t, err)
+
+	err = rd.SendConversion(entity.NewConversion("cadastro", &entity.Lead{Name: "Batata", Email: "batata@example.com"}))
+	require.NoError(t, err)
+
+	lead, ok := server.Lead("batata@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "Batata", lead.Name)
+
+	err = rd.SendOrderPlaced(&entity.Order{Email: "batata", OrderID: "1"})
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode(t, err))
+
+	results := rd.SendEventsBatch([]entity.Event{{
+		EventType:   entity.EventTypeOrderPlaced,
+		EventFamily: entity.EventFamilyCDP,
+		Payload:     entity.Order{Email: "batata@example.com", OrderID: "1"},
+	}})
+	require.NoError(t, results[0].Err)
+	assert.NotEmpty(t, results[0].UUID)
+
+	events := server.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, entity.EventTypeOrderPlaced, events[1].EventType)
+}
+
+func TestServer_Auth(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.AddLead(entity.Lead{Email: "batata@example.com"})
+
+	wrong, err := rdstation.New(ClientID, "errada", RefreshToken, rdstation.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	_, err = wrong.GetLeadByEmail("batata@example.com")
+	assert.Error(t, err)
+
+	rd, err := server.New()
+	require.NoError(t, err)
+
+	_, err = rd.GetLeadByEmail("batata@example.com")
+	require.NoError(t, err)
+
+	server.ExpireTokens()
+	_, err = rd.GetLeadByEmail("batata@example.com")
+	assert.Equal(t, http.StatusUnauthorized, statusCode(t, err))
+
+	t.Run("refresh", func(t *testing.T) {
+		server := NewServer(WithTokenTTL(time.Second))
+		defer server.Close()
+
+		rd, err := server.New()
+		require.NoError(t, err)
+
+		server.AddLead(entity.Lead{Email: "batata@example.com"})
+		_, err = rd.GetLeadByEmail("batata@example.com")
+		require.NoError(t, err)
+	})
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	server := NewServer(WithRateLimit(1, time.Minute))
+	defer server.Close()
+
+	rd, err := server.New()
+	require.NoError(t, err)
+
+	_, err = rd.AccountInfo()
+	require.NoError(t, err)
+
+	_, err = rd.AccountInfo()
+	var rdErr rdstation.RDError
+	require.True(t, errors.As(err, &rdErr))
+	assert.Equal(t, http.StatusTooManyRequests, rdErr.Errors.StatusCode)
+	assert.Equal(t, time.Minute, rdErr.Errors.RetryAfter)
+	assert.True(t, rdstation.IsRetriable(err))
+}
@@ -0,0 +1,134 @@
+package rdstation
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/internal/client"
+)
+
+var errBuildPanicked = errors.New("rdstation: building the tenant client panicked")
+
+// SecretLookup returns the credentials of a tenant.
+type SecretLookup func(tenant string) (entity.Secret, error)
+
+// Registry keeps one client per tenant, built on first use. Clients share
+// the registry options, so passing WithHTTPClient shares its transport and
+// WithRateLimit gives each tenant its own limit.
+//
+// A client whose credentials are rejected, by a 401 or a failed token
+// refresh, is evicted and built again, with fresh credentials, on the next
+// Get.
+type Registry struct {
+	lookup SecretLookup
+	opts   []Option
+
+	mu      sync.Mutex
+	tenants map[string]*tenant
+}
+
+type tenant struct {
+	ready chan struct{}
+	rd    RDStation
+	err   error
+}
+
+func NewRegistry(lookup SecretLookup, opts ...Option) *Registry {
+	return &Registry{
+		lookup:  lookup,
+		opts:    opts,
+		tenants: map[string]*tenant{},
+	}
+}
+
+// Get returns the client of key, building it if needed. Concurrent calls for
+// a tenant being built wait for the same client.
+func (r *Registry) Get(key string) (RDStation, error) {
+	r.mu.Lock()
+	t, ok := r.tenants[key]
+	if !ok {
+		t = &tenant{ready: make(chan struct{})}
+		r.tenants[key] = t
+	}
+	r.mu.Unlock()
+
+	if ok {
+		<-t.ready
+		return t.rd, t.err
+	}
+
+	// Until build returns, the error is the one waiters get if it panics.
+	t.err = errBuildPanicked
+	defer func() {
+		close(t.ready)
+
+		if t.err != nil {
+			r.evict(key, t)
+		}
+	}()
+
+	t.rd, t.err = r.build(key, t)
+
+	return t.rd, t.err
+}
+
+// Evict drops the client of key, the next Get builds a new one.
+func (r *Registry) Evict(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tenants, key)
+}
+
+func (r *Registry) evict(key string, t *tenant) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// a newer client may already be in place
+	if r.tenants[key] == t {
+		delete(r.tenants, key)
+	}
+}
+
+func (r *Registry) build(key string, t *tenant) (RDStation, error) {
+	secret, err := r.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append(append([]Option{}, r.opts...), func(o *options) {
+		o.wrap = func(cl client.Client) client.Client {
+			return evictingClient{Client: cl, evict: func() { r.evict(key, t) }}
+		}
+	})
+
+	return New(secret.ClientID, secret.ClientSecret, secret.RefreshToken, opts...)
+}
+
+type evictingClient struct {
+	client.Client
+	evict func()
+}
+
+func (c evictingClient) Request(path, method string, data []byte) ([]byte, error) {
+	result, err := c.Client.Request(path, method, data)
+	if unauthorized(err) {
+		c.evict()
+	}
+
+	return result, err
+}
+
+func unauthorized(err error) bool {
+	var rdErr client.RDError
+	if errors.As(err, &rdErr) {
+		return rdErr.Errors.StatusCode == http.StatusUnauthorized
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	return errors.As(err, &retrieveErr)
+}
@@ -0,0 +1,131 @@
+package rdstation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+func TestRegistry(t *testing.T) {
+	transport := httpmock.NewMockTransport()
+	httpClient := &http.Client{Transport: transport}
+
+	var exchanges int32
+	transport.RegisterResponder(http.MethodPost, "https://api.rd.services/auth/token",
+		func(r *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&exchanges, 1)
+			return httpmock.NewJsonResponse(http.StatusOK, entity.Token{
+				AccessToken:  fmt.Sprint("token-", n),
+				RefreshToken: "refresh",
+				ExpiresIn:    86400,
+			})
+		})
+
+	var unauthorized int32
+	transport.RegisterResponder(http.MethodGet, fmt.Sprintf("%s%semail:%s", RDURL, RDLeadPath, "email"),
+		func(r *http.Request) (*http.Response, error) {
+			if atomic.LoadInt32(&unauthorized) == 1 {
+				return httpmock.NewStringResponse(http.StatusUnauthorized,
+					`{"errors":{"error_type":"UNAUTHORIZED","error_message":"Invalid token."}}`), nil
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, entity.Lead{Email: "email"})
+		})
+
+	lookups := map[string]int{}
+	var mu sync.Mutex
+	registry := NewRegistry(func(tenant string) (entity.Secret, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if tenant == "desconhecido" {
+			return entity.Secret{}, errors.New("tenant not found")
+		}
+		lookups[tenant]++
+
+		return entity.Secret{ClientID: tenant, ClientSecret: "shhhhh", RefreshToken: "refresh"}, nil
+	}, WithHTTPClient(httpClient))
+
+	var wg sync.WaitGroup
+	clients := make([]RDStation, 10)
+	errs := make([]error, len(clients))
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			clients[i], errs[i] = registry.Get("marca-a")
+		}(i)
+	}
+	wg.Wait()
+
+	for i, rd := range clients {
+		require.NoError(t, errs[i])
+		require.Same(t, clients[0], rd)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&exchanges))
+
+	other, err := registry.Get("marca-b")
+	require.NoError(t, err)
+	require.NotSame(t, clients[0], other)
+	require.Equal(t, int32(2), atomic.LoadInt32(&exchanges))
+
+	lead, err := clients[0].GetLeadByEmail("email")
+	require.NoError(t, err)
+	require.Equal(t, "email", lead.Email)
+
+	t.Run("evicted on unauthorized", func(t *testing.T) {
+		atomic.StoreInt32(&unauthorized, 1)
+		_, err := clients[0].GetLeadByEmail("email")
+		require.Error(t, err)
+		atomic.StoreInt32(&unauthorized, 0)
+
+		rd, err := registry.Get("marca-a")
+		require.NoError(t, err)
+		require.NotSame(t, clients[0], rd)
+		require.Equal(t, 2, lookups["marca-a"])
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		rd, err := registry.Get("desconhecido")
+		require.Error(t, err)
+		require.Nil(t, rd)
+
+		_, err = registry.Get("desconhecido")
+		require.Error(t, err)
+	})
+
+	t.Run("evict", func(t *testing.T) {
+		registry.Evict("marca-b")
+
+		rd, err := registry.Get("marca-b")
+		require.NoError(t, err)
+		require.NotSame(t, other, rd)
+	})
+}
+
+func TestRegistry_Panic(t *testing.T) {
+	lookups := 0
+	registry := NewRegistry(func(tenant string) (entity.Secret, error) {
+		lookups++
+		if lookups == 1 {
+			panic("batata")
+		}
+
+		return entity.Secret{}, errors.New("tenant not found")
+	})
+
+	require.Panics(t, func() { _, _ = registry.Get("marca-a") })
+
+	rd, err := registry.Get("marca-a")
+	require.EqualError(t, err, "tenant not found")
+	require.Nil(t, rd)
+	require.Equal(t, 2, lookups)
+}
@@ -0,0 +1,47 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE
+package rdstation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+// MaxPageSize is the largest page the list endpoints return.
+const MaxPageSize = 125
+
+type SegmentationService interface {
+	ListSegmentations() ([]entity.Segmentation, error)
+	SegmentationContacts(ctx context.Context, segmentationID int, pageSize int) *Iterator[entity.Lead]
+}
+
+func (rd rdStation) ListSegmentations() ([]entity.Segmentation, error) {
+	it := NewPageIterator(context.Background(), MaxPageSize, func(ctx context.Context, page, pageSize int) ([]entity.Segmentation, error) {
+		var response struct {
+			Segmentations []entity.Segmentation `json:"segmentations"`
+		}
+		err := rd.get(pageURL(fmt.Sprintf("%s%s", RDURL, RDSegmentationsPath), page, pageSize), &response)
+
+		return response.Segmentations, err
+	})
+
+	return Collect(it, 0)
+}
+
+// SegmentationContacts iterates over the contacts of a segmentation, fetching
+// pageSize contacts per request.
+func (rd rdStation) SegmentationContacts(ctx context.Context, segmentationID int, pageSize int) *Iterator[entity.Lead] {
+	if pageSize <= 0 || pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	return NewPageIterator(ctx, pageSize, func(ctx context.Context, page, pageSize int) ([]entity.Lead, error) {
+		var response struct {
+			Contacts []entity.Lead `json:"contacts"`
+		}
+		err := rd.get(pageURL(fmt.Sprintf("%s%s/%d/contacts", RDURL, RDSegmentationsPath, segmentationID), page, pageSize), &response)
+
+		return response.Contacts, err
+	})
+}
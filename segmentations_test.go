@@ -0,0 +1,81 @@
+package rdstation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_ListSegmentations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	full := make([]entity.Segmentation, MaxPageSize)
+	for i := range full {
+		full[i] = entity.Segmentation{ID: i, Name: fmt.Sprint("segmentacao ", i)}
+	}
+	first, err := json.Marshal(map[string][]entity.Segmentation{"segmentations": full})
+	require.NoError(t, err)
+	second, err := json.Marshal(map[string][]entity.Segmentation{"segmentations": {{ID: 999, Name: "ultima"}}})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("%s%s", RDURL, RDSegmentationsPath)
+	client.EXPECT().Request(fmt.Sprintf("%s?page=1&page_size=%d", url, MaxPageSize), http.MethodGet, nil).Return(first, nil)
+	client.EXPECT().Request(fmt.Sprintf("%s?page=2&page_size=%d", url, MaxPageSize), http.MethodGet, nil).Return(second, nil)
+
+	got, err := rd.ListSegmentations()
+	require.NoError(t, err)
+	require.Len(t, got, MaxPageSize+1)
+	require.Equal(t, "ultima", got[MaxPageSize].Name)
+}
+
+func TestRdStation_SegmentationContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	url := fmt.Sprintf("%s%s/%d/contacts", RDURL, RDSegmentationsPath, 7)
+
+	t.Run("pages", func(t *testing.T) {
+		first := []byte(`{"contacts":[{"uuid":"1","email":"a@email.com"},{"uuid":"2","email":"b@email.com"}]}`)
+		second := []byte(`{"contacts":[{"uuid":"3","email":"c@email.com"}]}`)
+
+		client.EXPECT().Request(url+"?page=1&page_size=2", http.MethodGet, nil).Return(first, nil)
+		client.EXPECT().Request(url+"?page=2&page_size=2", http.MethodGet, nil).Return(second, nil)
+
+		var emails []string
+		it := rd.SegmentationContacts(context.Background(), 7, 2)
+		for it.Next() {
+			emails = append(emails, it.Value().Email)
+		}
+
+		require.NoError(t, it.Err())
+		require.Equal(t, []string{"a@email.com", "b@email.com", "c@email.com"}, emails)
+		require.False(t, it.Next())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client.EXPECT().Request(fmt.Sprintf("%s?page=1&page_size=%d", url, MaxPageSize), http.MethodGet, nil).
+			Return(nil, errors.New("batata"))
+
+		it := rd.SegmentationContacts(context.Background(), 7, 0)
+		require.False(t, it.Next())
+		require.Error(t, it.Err())
+	})
+}
@@ -9,12 +9,43 @@ const (
 	AcademyTagCancelled = entity.AcademyTagCancelled
 	AcademyActive       = entity.AcademyActive
 	EmailOptOut         = entity.EmailOptOut
+
+	EventTypeConversion        = entity.EventTypeConversion
+	EventTypeOrderPlaced       = entity.EventTypeOrderPlaced
+	EventTypeOrderPlacedItem   = entity.EventTypeOrderPlacedItem
+	EventTypeCartAbandoned     = entity.EventTypeCartAbandoned
+	EventTypeCartAbandonedItem = entity.EventTypeCartAbandonedItem
+
+	LifecycleLead          = entity.LifecycleLead
+	LifecycleQualifiedLead = entity.LifecycleQualifiedLead
+	LifecycleClient        = entity.LifecycleClient
 )
 
 type (
-	Lead    = entity.Lead
-	Token   = entity.Token
-	Secret  = entity.Secret
-	RDError = client.RDError
-	Errors  = client.Errors
+	Lead        = entity.Lead
+	Token       = entity.Token
+	Secret      = entity.Secret
+	AccountInfo = entity.AccountInfo
+	Event       = entity.Event
+	Conversion  = entity.Conversion
+	Order       = entity.Order
+	OrderItem   = entity.OrderItem
+	Cart        = entity.Cart
+	CartItem    = entity.CartItem
+
+	WebhookSubscription = entity.WebhookSubscription
+	Segmentation        = entity.Segmentation
+	DateRange           = entity.DateRange
+	EmailAnalytics      = entity.EmailAnalytics
+	ConversionAnalytics = entity.ConversionAnalytics
+	FunnelAnalytics     = entity.FunnelAnalytics
+	Email               = entity.Email
+	EmailFilter         = entity.EmailFilter
+	LandingPage         = entity.LandingPage
+	Popup               = entity.Popup
+	Workflow            = entity.Workflow
+	Funnel              = entity.Funnel
+	RDError             = client.RDError
+	Errors              = client.Errors
+	AccountError        = client.AccountError
 )
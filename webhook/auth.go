@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Authenticator tells whether a webhook request comes from RD Station.
+type Authenticator interface {
+	Authenticate(r *http.Request) bool
+}
+
+type AuthenticatorFunc func(r *http.Request) bool
+
+func (f AuthenticatorFunc) Authenticate(r *http.Request) bool {
+	return f(r)
+}
+
+// SharedSecret accepts requests carrying secret in the given header. RD
+// Station does not sign webhooks, so the secret is usually set as a header
+// of the subscription or through a proxy in front of the handler. An empty
+// secret is an error, it would accept requests without the header.
+func SharedSecret(header, secret string) (Authenticator, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("webhook: empty secret for header %q", header)
+	}
+
+	return AuthenticatorFunc(func(r *http.Request) bool {
+		return equal(r.Header.Get(header), secret)
+	}), nil
+}
+
+// BasicAuth accepts requests with the given credentials, which RD Station
+// sends when they are part of the subscription url.
+func BasicAuth(username, password string) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request) bool {
+		u, p, ok := r.BasicAuth()
+		// both sides are compared to not leak which one is wrong
+		validUser := equal(u, username)
+		validPassword := equal(p, password)
+
+		return ok && validUser && validPassword
+	})
+}
+
+// IPAllowlist accepts requests whose remote address is in one of the CIDR
+// ranges or single addresses given. Only the connection address is checked,
+// handlers behind a proxy must restore it before this runs.
+func IPAllowlist(ranges ...string) (Authenticator, error) {
+	networks := make([]*net.IPNet, 0, len(ranges))
+	for _, r := range ranges {
+		if ip := net.ParseIP(r); ip != nil {
+			bits := 8 * len(ip.To4())
+			if bits == 0 {
+				bits = 8 * net.IPv6len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, fmt.Errorf("webhook: invalid address range %q: %w", r, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return AuthenticatorFunc(func(r *http.Request) bool {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return false
+		}
+
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+
+		return false
+	}), nil
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,191 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticators(t *testing.T) {
+	request := func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+		r.RemoteAddr = "10.1.2.3:4567"
+		return r
+	}
+
+	t.Run("shared secret", func(t *testing.T) {
+		auth, err := SharedSecret("X-Webhook-Secret", "shhhhh")
+		require.NoError(t, err)
+
+		r := request()
+		require.False(t, auth.Authenticate(r))
+
+		r.Header.Set("X-Webhook-Secret", "shhhhh")
+		require.True(t, auth.Authenticate(r))
+
+		_, err = SharedSecret("X-Webhook-Secret", "")
+		require.Error(t, err)
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		auth := BasicAuth("rd", "shhhhh")
+
+		r := request()
+		require.False(t, auth.Authenticate(r))
+
+		r.SetBasicAuth("rd", "errado")
+		require.False(t, auth.Authenticate(r))
+
+		r.SetBasicAuth("rd", "shhhhh")
+		require.True(t, auth.Authenticate(r))
+	})
+
+	t.Run("ip allowlist", func(t *testing.T) {
+		auth, err := IPAllowlist("10.1.0.0/16", "192.168.0.1")
+		require.NoError(t, err)
+
+		r := request()
+		require.True(t, auth.Authenticate(r))
+
+		r.RemoteAddr = "192.168.0.1:80"
+		require.True(t, auth.Authenticate(r))
+
+		r.RemoteAddr = "192.168.0.2:80"
+		require.False(t, auth.Authenticate(r))
+
+		_, err = IPAllowlist("batata")
+		require.Error(t, err)
+	})
+}
+
+func TestHandler_Authenticate(t *testing.T) {
+	auth, err := SharedSecret("X-Webhook-Secret", "shhhhh")
+	require.NoError(t, err)
+
+	handler := NewHandler()
+	handler.Authenticate(auth)
+
+	called := false
+	handler.On(EventConverted, func(ctx context.Context, eventType string, lead Lead) error {
+		called = true
+		return nil
+	})
+
+	recorder := post(handler, "/webhook", payload)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.False(t, called)
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	r.Header.Set("X-Webhook-Secret", "shhhhh")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.True(t, called)
+}
+
+func TestHandler_Deduplicate(t *testing.T) {
+	handler := NewHandler()
+	handler.Deduplicate(NewMemorySeenStore(), time.Hour)
+
+	calls := 0
+	fail := true
+	handler.On(EventConverted, func(ctx context.Context, eventType string, lead Lead) error {
+		calls++
+		if fail {
+			fail = false
+			return context.DeadlineExceeded
+		}
+		return nil
+	})
+
+	require.Equal(t, http.StatusInternalServerError, post(handler, "/webhook", payload).Code)
+	require.Equal(t, http.StatusOK, post(handler, "/webhook", payload).Code)
+	require.Equal(t, http.StatusOK, post(handler, "/webhook", payload).Code)
+	require.Equal(t, 2, calls)
+
+	newConversion := strings.Replace(payload, "2022-07-08T10:00:00.000-03:00", "2022-07-09T10:00:00.000-03:00", 1)
+	require.Equal(t, http.StatusOK, post(handler, "/webhook", newConversion).Code)
+	require.Equal(t, 3, calls)
+}
+
+func TestHandler_DeduplicateConcurrent(t *testing.T) {
+	handler := NewHandler()
+	handler.Deduplicate(NewMemorySeenStore(), time.Hour)
+
+	var calls int32
+	release := make(chan struct{})
+	handler.On(EventConverted, func(ctx context.Context, eventType string, lead Lead) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	codes := make([]int, 5)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i] = post(handler, "/webhook", payload).Code
+		}(i)
+	}
+
+	// Let the redeliveries reach the store while the first one is running.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, code := range codes {
+		require.Equal(t, http.StatusOK, code)
+	}
+}
+
+func TestEventID(t *testing.T) {
+	lead := Lead{}
+	require.Empty(t, EventID(EventConverted, lead))
+
+	lead.Email = "batata@example.com"
+	byEmail := EventID(EventConverted, lead)
+	require.NotEmpty(t, byEmail)
+	require.NotEqual(t, byEmail, EventID(EventMarkedOpportunity, lead))
+
+	lead.Uuid = "batata"
+	require.NotEqual(t, byEmail, EventID(EventConverted, lead))
+
+	other := Lead{}
+	other.Email = "outra@example.com"
+	require.NotEqual(t, byEmail, EventID(EventConverted, other))
+}
+
+func TestMemorySeenStore(t *testing.T) {
+	store := NewMemorySeenStore()
+
+	claimed, err := store.Claim("a", time.Hour)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = store.Claim("a", time.Hour)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	require.NoError(t, store.Release("a"))
+	claimed, err = store.Claim("a", time.Hour)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = store.Claim("b", -time.Second)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = store.Claim("b", time.Hour)
+	require.NoError(t, err)
+	require.True(t, claimed)
+}
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// SeenStore remembers the events already processed. A delivery claims its
+// key before the callbacks run, so concurrent redeliveries run them once, and
+// releases it when a callback fails, so the delivery can still be retried.
+type SeenStore interface {
+	// Claim marks key as seen for ttl and reports whether it was not already,
+	// atomically.
+	Claim(key string, ttl time.Duration) (bool, error)
+	Release(key string) error
+}
+
+// EventID identifies a delivery of lead for eventType. Redeliveries of the
+// same conversion or opportunity share the id, new conversions do not. Leads
+// without uuid are identified by their email, and leads without either have
+// no id and are not deduplicated.
+func EventID(eventType string, lead Lead) string {
+	key := lead.Uuid
+	if key == "" {
+		if lead.Email == "" {
+			return ""
+		}
+		key = "email:" + lead.Email
+	}
+
+	id := eventType + "|" + key
+	if lead.LastConversion != nil {
+		id += "|" + lead.LastConversion.CreatedAt
+	}
+
+	return id + "|" + lead.LastMarkedOpportunityDate
+}
+
+type MemorySeenStore struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	sweep   time.Time
+}
+
+func NewMemorySeenStore() *MemorySeenStore {
+	return &MemorySeenStore{expires: map[string]time.Time{}}
+}
+
+func (m *MemorySeenStore) Claim(key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if expires, ok := m.expires[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+
+	m.expires[key] = now.Add(ttl)
+
+	if now.After(m.sweep) {
+		for k, expires := range m.expires {
+			if now.After(expires) {
+				delete(m.expires, k)
+			}
+		}
+		m.sweep = now.Add(ttl)
+	}
+
+	return true, nil
+}
+
+func (m *MemorySeenStore) Release(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.expires, key)
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// Package webhook receives the contact webhooks RD Station posts to an
+// integration url.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MaxBodySize caps the size of a webhook request body.
+const MaxBodySize = 10 << 20
+
+// HandlerFunc processes one lead of a webhook. Returning an error answers
+// the request with a 500, so RD Station delivers it again later.
+type HandlerFunc func(ctx context.Context, eventType string, lead Lead) error
+
+// Handler is an http.Handler that decodes webhook payloads and calls the
+// functions registered for their event type.
+//
+// The event type is read from the payload, then from the event_type query
+// parameter, so a single handler can serve several subscriptions if their
+// urls carry it. Payloads without either are treated as EventConverted.
+type Handler struct {
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+
+	authenticators []Authenticator
+	seen           SeenStore
+	window         time.Duration
+}
+
+func NewHandler() *Handler {
+	return &Handler{handlers: map[string][]HandlerFunc{}}
+}
+
+// On registers fn for eventType. Several functions may be registered for the
+// same type, they run in registration order.
+func (h *Handler) On(eventType string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// Authenticate makes the handler reject with 401 any request that is not
+// accepted by all the authenticators.
+func (h *Handler) Authenticate(authenticators ...Authenticator) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.authenticators = append(h.authenticators, authenticators...)
+}
+
+// Deduplicate skips leads whose EventID was processed in the last window,
+// acknowledging them without calling the registered functions again.
+func (h *Handler) Deduplicate(store SeenStore, window time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seen = store
+	h.window = window
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.mu.RLock()
+	authenticators, seen, window := h.authenticators, h.seen, h.window
+	h.mu.RUnlock()
+
+	for _, authenticator := range authenticators {
+		if !authenticator.Authenticate(r) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var payload Payload
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize)).Decode(&payload)
+	if err != nil {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	eventType := eventType(r, payload)
+
+	h.mu.RLock()
+	handlers := h.handlers[eventType]
+	h.mu.RUnlock()
+
+	for _, lead := range payload.Leads {
+		err = dispatch(r.Context(), seen, window, handlers, eventType, lead)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func dispatch(ctx context.Context, seen SeenStore, window time.Duration, handlers []HandlerFunc, eventType string, lead Lead) error {
+	id := ""
+	if seen != nil {
+		id = EventID(eventType, lead)
+	}
+	if id == "" {
+		return call(ctx, handlers, eventType, lead)
+	}
+
+	claimed, err := seen.Claim(id, window)
+	if err != nil || !claimed {
+		return err
+	}
+
+	err = call(ctx, handlers, eventType, lead)
+	if err != nil {
+		// The error of the callback matters more, a failed release only
+		// makes RD Station retries be skipped until the window ends.
+		_ = seen.Release(id)
+		return err
+	}
+
+	return nil
+}
+
+func call(ctx context.Context, handlers []HandlerFunc, eventType string, lead Lead) error {
+	for _, fn := range handlers {
+		err := fn(ctx, eventType, lead)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func eventType(r *http.Request, payload Payload) string {
+	if payload.EventType != "" {
+		return payload.EventType
+	}
+
+	if eventType := r.URL.Query().Get("event_type"); eventType != "" {
+		return eventType
+	}
+
+	return EventConverted
+}
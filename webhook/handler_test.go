@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const payload = `{
+	"leads": [{
+		"id": "123",
+		"uuid": "c2f3d2b3-7250-4d27-97f4-eef38be32f7f",
+		"email": "biro@email.com",
+		"name": "biro",
+		"company": "Qual",
+		"job_title": "dev",
+		"opportunity": "false",
+		"number_conversions": "2",
+		"first_conversion": {
+			"content": {"identificador": "newsletter", "email_lead": "biro@email.com"},
+			"created_at": "2022-07-06T11:54:26.542-03:00",
+			"cumulative_sum": "1",
+			"source": "newsletter",
+			"conversion_origin": {"source": "google", "medium": "cpc", "channel": "Paid Search"}
+		},
+		"last_conversion": {
+			"content": {"identificador": "demo"},
+			"created_at": "2022-07-08T10:00:00.000-03:00",
+			"cumulative_sum": "2",
+			"source": "demo"
+		},
+		"custom_fields": {"Plano": "anual"},
+		"tags": ["doce", "solidao"],
+		"lead_stage": "Lead",
+		"fit_score": "a",
+		"interest": 20
+	}]
+}`
+
+func post(h http.Handler, target, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+
+	return recorder
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler()
+
+	var received []Lead
+	handler.On(EventConverted, func(ctx context.Context, eventType string, lead Lead) error {
+		require.Equal(t, EventConverted, eventType)
+		received = append(received, lead)
+		return nil
+	})
+
+	recorder := post(handler, "/webhook", payload)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, received, 1)
+
+	lead := received[0]
+	require.Equal(t, "biro@email.com", lead.Email)
+	require.Equal(t, []string{"doce", "solidao"}, lead.Tags)
+	require.Equal(t, "Qual", lead.Company)
+	require.Equal(t, "newsletter", lead.FirstConversion.Identifier())
+	require.Equal(t, "demo", lead.LastConversion.Identifier())
+	require.Equal(t, "Paid Search", lead.FirstConversion.ConversionOrigin.Channel)
+	require.Equal(t, "anual", lead.CustomFieldsByLabel["Plano"])
+	require.Equal(t, 20, lead.Interest)
+
+	t.Run("event type from query", func(t *testing.T) {
+		called := false
+		handler.On(EventMarkedOpportunity, func(ctx context.Context, eventType string, lead Lead) error {
+			called = true
+			return nil
+		})
+
+		recorder := post(handler, "/webhook?event_type=WEBHOOK.MARKED_OPPORTUNITY", payload)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.True(t, called)
+		require.Len(t, received, 1)
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		handler := NewHandler()
+		handler.On(EventConverted, func(ctx context.Context, eventType string, lead Lead) error {
+			return errors.New("batata")
+		})
+
+		recorder := post(handler, "/webhook", payload)
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		recorder := post(handler, "/webhook", "{")
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestLead_MarshalJSON(t *testing.T) {
+	var p Payload
+	require.NoError(t, json.Unmarshal([]byte(payload), &p))
+
+	data, err := json.Marshal(p.Leads[0])
+	require.NoError(t, err)
+
+	var lead Lead
+	require.NoError(t, json.Unmarshal(data, &lead))
+	require.Equal(t, p.Leads[0], lead)
+}
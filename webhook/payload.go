@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+const (
+	EventConverted         = entity.WebhookEventConverted
+	EventMarkedOpportunity = entity.WebhookEventMarkedOpportunity
+)
+
+// Payload is the body RD Station posts to a webhook url.
+type Payload struct {
+	EventType string `json:"event_type,omitempty"`
+	Leads     []Lead `json:"leads"`
+}
+
+// Lead is a contact as sent by webhooks: the regular lead fields plus the
+// conversion history and scoring RD Station attaches to it. Webhooks key
+// custom fields by their label instead of the cf_* identifier.
+type Lead struct {
+	entity.Lead
+	Details
+}
+
+type Details struct {
+	ID                        string                 `json:"id,omitempty"`
+	Company                   string                 `json:"company,omitempty"`
+	PublicURL                 string                 `json:"public_url,omitempty"`
+	CreatedAt                 string                 `json:"created_at,omitempty"`
+	Opportunity               string                 `json:"opportunity,omitempty"`
+	NumberConversions         string                 `json:"number_conversions,omitempty"`
+	User                      string                 `json:"user,omitempty"`
+	LeadStage                 string                 `json:"lead_stage,omitempty"`
+	LastMarkedOpportunityDate string                 `json:"last_marked_opportunity_date,omitempty"`
+	FitScore                  string                 `json:"fit_score,omitempty"`
+	Interest                  int                    `json:"interest,omitempty"`
+	FirstConversion           *Conversion            `json:"first_conversion,omitempty"`
+	LastConversion            *Conversion            `json:"last_conversion,omitempty"`
+	CustomFieldsByLabel       map[string]interface{} `json:"custom_fields,omitempty"`
+	UncertifiedFields         []string               `json:"uncertified_fields,omitempty"`
+}
+
+type Conversion struct {
+	Content          map[string]interface{} `json:"content,omitempty"`
+	CreatedAt        string                 `json:"created_at,omitempty"`
+	CumulativeSum    string                 `json:"cumulative_sum,omitempty"`
+	Source           string                 `json:"source,omitempty"`
+	ConversionOrigin *ConversionOrigin      `json:"conversion_origin,omitempty"`
+}
+
+type ConversionOrigin struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Channel  string `json:"channel,omitempty"`
+}
+
+// Identifier returns the conversion identifier of the conversion, the
+// "identificador" content key used by RD Station forms.
+func (c *Conversion) Identifier() string {
+	if c == nil {
+		return ""
+	}
+
+	identifier, _ := c.Content["identificador"].(string)
+	return identifier
+}
+
+// UnmarshalJSON decodes both halves of the lead, otherwise the method
+// promoted from entity.Lead would skip the webhook details.
+func (l *Lead) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &l.Lead)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &l.Details)
+}
+
+func (l Lead) MarshalJSON() ([]byte, error) {
+	lead, err := json.Marshal(l.Lead)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(lead, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := json.Marshal(l.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(details, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/flan6/rdstation"
+	"github.com/flan6/rdstation/entity"
+)
+
+// ReconcileResult lists the subscriptions changed by Reconcile.
+type ReconcileResult struct {
+	Created []entity.WebhookSubscription
+	Updated []entity.WebhookSubscription
+	Deleted []entity.WebhookSubscription
+}
+
+// Reconcile makes the account subscriptions match desired. Subscriptions are
+// matched by event type and url: missing ones are created, the ones that
+// differ are updated and the ones not in desired are deleted, as are the
+// duplicates of a matched subscription. Desired subscriptions must not repeat
+// an event type and url.
+func Reconcile(rd rdstation.WebhookService, desired []entity.WebhookSubscription) (ReconcileResult, error) {
+	var result ReconcileResult
+
+	wanted := map[string]bool{}
+	for _, webhook := range desired {
+		key := subscriptionKey(webhook)
+		if wanted[key] {
+			return result, fmt.Errorf("webhook: duplicate subscription for %s", key)
+		}
+		wanted[key] = true
+	}
+
+	current, err := rd.ListWebhooks()
+	if err != nil {
+		return result, err
+	}
+
+	existing := map[string][]entity.WebhookSubscription{}
+	for _, webhook := range current {
+		key := subscriptionKey(webhook)
+		existing[key] = append(existing[key], webhook)
+	}
+
+	kept := map[string]bool{}
+	for _, webhook := range desired {
+		matches := existing[subscriptionKey(webhook)]
+		if len(matches) == 0 {
+			created, err := rd.CreateWebhook(&webhook)
+			if err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, *created)
+			continue
+		}
+
+		found := matches[0]
+		kept[found.UUID] = true
+
+		webhook.UUID = found.UUID
+		if sameSubscription(found, webhook) {
+			continue
+		}
+
+		updated, err := rd.UpdateWebhook(&webhook)
+		if err != nil {
+			return result, err
+		}
+		result.Updated = append(result.Updated, *updated)
+	}
+
+	for _, webhook := range current {
+		if kept[webhook.UUID] {
+			continue
+		}
+
+		err = rd.DeleteWebhook(webhook.UUID)
+		if err != nil {
+			return result, err
+		}
+		result.Deleted = append(result.Deleted, webhook)
+	}
+
+	return result, nil
+}
+
+func subscriptionKey(webhook entity.WebhookSubscription) string {
+	return webhook.EventType + " " + webhook.URL
+}
+
+func sameSubscription(a, b entity.WebhookSubscription) bool {
+	return a.EntityType == b.EntityType &&
+		a.HTTPMethod == b.HTTPMethod &&
+		reflect.DeepEqual(sorted(a.EventIdentifiers), sorted(b.EventIdentifiers)) &&
+		reflect.DeepEqual(sorted(a.IncludeRelations), sorted(b.IncludeRelations))
+}
+
+func sorted(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	values = append([]string{}, values...)
+	sort.Strings(values)
+
+	return values
+}
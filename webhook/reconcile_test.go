@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type fakeWebhooks struct {
+	webhooks []entity.WebhookSubscription
+	next     int
+}
+
+func (f *fakeWebhooks) ListWebhooks() ([]entity.WebhookSubscription, error) {
+	return append([]entity.WebhookSubscription{}, f.webhooks...), nil
+}
+
+func (f *fakeWebhooks) CreateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	f.next++
+	created := *webhook
+	created.UUID = fmt.Sprint("new-", f.next)
+	f.webhooks = append(f.webhooks, created)
+
+	return &created, nil
+}
+
+func (f *fakeWebhooks) UpdateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	for i := range f.webhooks {
+		if f.webhooks[i].UUID == webhook.UUID {
+			f.webhooks[i] = *webhook
+		}
+	}
+
+	return webhook, nil
+}
+
+func (f *fakeWebhooks) DeleteWebhook(uuid string) error {
+	for i := range f.webhooks {
+		if f.webhooks[i].UUID == uuid {
+			f.webhooks = append(f.webhooks[:i], f.webhooks[i+1:]...)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func TestReconcile(t *testing.T) {
+	unchanged := entity.WebhookSubscription{
+		EntityType:       entity.WebhookEntityContact,
+		EventType:        EventConverted,
+		URL:              "https://qual.work/converted",
+		HTTPMethod:       "POST",
+		EventIdentifiers: []string{"b", "a"},
+	}
+	changed := entity.WebhookSubscription{
+		EntityType: entity.WebhookEntityContact,
+		EventType:  EventMarkedOpportunity,
+		URL:        "https://qual.work/opportunity",
+		HTTPMethod: "POST",
+	}
+	stale := entity.WebhookSubscription{
+		UUID:       "3",
+		EntityType: entity.WebhookEntityContact,
+		EventType:  EventConverted,
+		URL:        "https://old.qual.work",
+		HTTPMethod: "POST",
+	}
+
+	current := []entity.WebhookSubscription{unchanged, changed, stale}
+	current[0].UUID, current[1].UUID = "1", "2"
+	current[0].EventIdentifiers = []string{"a", "b"}
+	current[1].HTTPMethod = "PUT"
+
+	fake := &fakeWebhooks{webhooks: current}
+	fresh := entity.WebhookSubscription{
+		EntityType: entity.WebhookEntityContact,
+		EventType:  EventConverted,
+		URL:        "https://qual.work/new",
+		HTTPMethod: "POST",
+	}
+
+	result, err := Reconcile(fake, []entity.WebhookSubscription{unchanged, changed, fresh})
+	require.NoError(t, err)
+
+	require.Len(t, result.Created, 1)
+	require.Equal(t, "new-1", result.Created[0].UUID)
+	require.Len(t, result.Updated, 1)
+	require.Equal(t, "2", result.Updated[0].UUID)
+	require.Equal(t, "POST", result.Updated[0].HTTPMethod)
+	require.Equal(t, []entity.WebhookSubscription{stale}, result.Deleted)
+	require.Len(t, fake.webhooks, 3)
+
+	result, err = Reconcile(fake, []entity.WebhookSubscription{unchanged, changed, fresh})
+	require.NoError(t, err)
+	require.Equal(t, ReconcileResult{}, result)
+}
+
+func TestReconcile_Duplicates(t *testing.T) {
+	webhook := entity.WebhookSubscription{
+		EntityType: entity.WebhookEntityContact,
+		EventType:  EventConverted,
+		URL:        "https://qual.work/converted",
+		HTTPMethod: "POST",
+	}
+
+	current := []entity.WebhookSubscription{webhook, webhook, webhook}
+	current[0].UUID, current[1].UUID, current[2].UUID = "1", "2", "3"
+
+	fake := &fakeWebhooks{webhooks: append([]entity.WebhookSubscription{}, current...)}
+	result, err := Reconcile(fake, []entity.WebhookSubscription{webhook})
+	require.NoError(t, err)
+	require.Empty(t, result.Created)
+	require.Empty(t, result.Updated)
+	require.Equal(t, current[1:], result.Deleted)
+	require.Equal(t, current[:1], fake.webhooks)
+
+	_, err = Reconcile(fake, []entity.WebhookSubscription{webhook, webhook})
+	require.Error(t, err)
+	require.Equal(t, current[:1], fake.webhooks)
+}
@@ -0,0 +1,67 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE
+package rdstation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+type WebhookService interface {
+	ListWebhooks() ([]entity.WebhookSubscription, error)
+	CreateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	UpdateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	DeleteWebhook(uuid string) error
+}
+
+func (rd rdStation) ListWebhooks() ([]entity.WebhookSubscription, error) {
+	data, err := rd.client.Request(fmt.Sprintf("%s%s", RDURL, RDWebhooksPath), http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Webhooks []entity.WebhookSubscription `json:"webhooks"`
+	}
+	err = json.Unmarshal(data, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Webhooks, nil
+}
+
+func (rd rdStation) CreateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	return rd.saveWebhook(fmt.Sprintf("%s%s", RDURL, RDWebhooksPath), http.MethodPost, webhook)
+}
+
+func (rd rdStation) UpdateWebhook(webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	return rd.saveWebhook(fmt.Sprintf("%s%s/%s", RDURL, RDWebhooksPath, webhook.UUID), http.MethodPut, webhook)
+}
+
+func (rd rdStation) DeleteWebhook(uuid string) error {
+	_, err := rd.client.Request(fmt.Sprintf("%s%s/%s", RDURL, RDWebhooksPath, uuid), http.MethodDelete, nil)
+	return err
+}
+
+func (rd rdStation) saveWebhook(url, method string, webhook *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = rd.client.Request(url, method, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved entity.WebhookSubscription
+	err = json.Unmarshal(data, &saved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
@@ -0,0 +1,78 @@
+package rdstation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_Webhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	url := fmt.Sprintf("%s%s", RDURL, RDWebhooksPath)
+	webhook := entity.WebhookSubscription{
+		EntityType:       entity.WebhookEntityContact,
+		EventType:        entity.WebhookEventConverted,
+		EventIdentifiers: []string{"newsletter"},
+		URL:              "https://qual.work/webhook",
+		HTTPMethod:       http.MethodPost,
+	}
+	saved := webhook
+	saved.UUID = "5408c5a3-4711-4f2e-8d0b-13407a3e30f3"
+
+	t.Run("list", func(t *testing.T) {
+		response, err := json.Marshal(map[string][]entity.WebhookSubscription{"webhooks": {saved}})
+		require.NoError(t, err)
+
+		client.EXPECT().Request(url, http.MethodGet, nil).Return(response, nil)
+
+		got, err := rd.ListWebhooks()
+		require.NoError(t, err)
+		require.Equal(t, []entity.WebhookSubscription{saved}, got)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		data, err := json.Marshal(webhook)
+		require.NoError(t, err)
+		response, err := json.Marshal(saved)
+		require.NoError(t, err)
+
+		client.EXPECT().Request(url, http.MethodPost, data).Return(response, nil)
+
+		got, err := rd.CreateWebhook(&webhook)
+		require.NoError(t, err)
+		require.Equal(t, saved, *got)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		data, err := json.Marshal(saved)
+		require.NoError(t, err)
+
+		client.EXPECT().Request(fmt.Sprintf("%s/%s", url, saved.UUID), http.MethodPut, data).
+			Return(nil, errors.New("batata"))
+
+		got, err := rd.UpdateWebhook(&saved)
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		client.EXPECT().Request(fmt.Sprintf("%s/%s", url, saved.UUID), http.MethodDelete, nil).Return(nil, nil)
+
+		err := rd.DeleteWebhook(saved.UUID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,51 @@
+//go:generate go run github.com/golang/mock/mockgen -package=rdstationtest -self_package=github.com/flan6/rdstation/rdstationtest -source=$GOFILE -destination=rdstationtest/mock_$GOFILE
+package rdstation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flan6/rdstation/entity"
+)
+
+var ErrNoConversionEntry = errors.New("workflow has no conversion entry criteria")
+
+type WorkflowService interface {
+	ListWorkflows(ctx context.Context) *Iterator[entity.Workflow]
+	EnrollInWorkflow(workflow *entity.Workflow, lead *entity.Lead, identifier string) error
+}
+
+func (rd rdStation) ListWorkflows(ctx context.Context) *Iterator[entity.Workflow] {
+	return NewPageIterator(ctx, MaxPageSize, func(ctx context.Context, page, pageSize int) ([]entity.Workflow, error) {
+		var response struct {
+			Workflows []entity.Workflow `json:"workflows"`
+		}
+		err := rd.get(pageURL(fmt.Sprintf("%s%s", RDURL, RDWorkflowsPath), page, pageSize), &response)
+
+		return response.Workflows, err
+	})
+}
+
+// EnrollInWorkflow makes the lead enter the workflow by sending identifier,
+// which must be one of the conversions of its entry criteria. An empty
+// identifier sends the first one.
+//
+// RD Station decides whether the contact actually enters, for instance paused
+// workflows accept no one, and the api does not list the contacts of a
+// workflow, so the enrollment can not be checked afterwards.
+func (rd rdStation) EnrollInWorkflow(workflow *entity.Workflow, lead *entity.Lead, identifier string) error {
+	identifiers := workflow.ConversionIdentifiers()
+	if len(identifiers) == 0 {
+		return fmt.Errorf("%w: %s", ErrNoConversionEntry, workflow.Name)
+	}
+
+	if identifier == "" {
+		identifier = identifiers[0]
+	}
+	if !contains(identifiers, identifier) {
+		return fmt.Errorf("%w %q: %s", ErrNoConversionEntry, identifier, workflow.Name)
+	}
+
+	return rd.SendConversion(entity.NewConversion(identifier, lead))
+}
@@ -0,0 +1,106 @@
+package rdstation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flan6/rdstation/entity"
+	"github.com/flan6/rdstation/test/mocks"
+)
+
+func TestRdStation_ListWorkflows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	url := pageURL(fmt.Sprintf("%s%s", RDURL, RDWorkflowsPath), 1, MaxPageSize)
+	client.EXPECT().Request(url, http.MethodGet, nil).Return([]byte(`{"workflows": [{
+		"id": "wf-1",
+		"name": "Nutrição ebook",
+		"status": "ACTIVE",
+		"entry_criteria": [
+			{"type": "SEGMENTATION", "segmentation_id": 7},
+			{"type": "CONVERSION", "conversion_identifiers": ["lp-ebook", "popup-ebook"]}
+		]
+	}]}`), nil)
+
+	got, err := Collect(rd.ListWorkflows(context.Background()), 0)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, entity.WorkflowStatusActive, got[0].Status)
+	require.Equal(t, []string{"lp-ebook", "popup-ebook"}, got[0].ConversionIdentifiers())
+}
+
+func TestRdStation_EnrollInWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mocks.NewMockClient(ctrl)
+
+	rd := &rdStation{client: client}
+	require.NotNil(t, rd)
+
+	lead := entity.Lead{
+		Uuid:         "aksjdnasd",
+		Name:         "nome",
+		Email:        "email@email.com",
+		CustomFields: map[string]interface{}{"cf_plano": "anual"},
+	}
+
+	workflow := entity.Workflow{Name: "ebook", EntryCriteria: []entity.WorkflowEntryCriterion{
+		{Type: entity.WorkflowEntryConversion, ConversionIdentifiers: []string{"lp-ebook", "popup-ebook"}},
+	}}
+
+	conversion := func(identifier string) []byte {
+		data, err := json.Marshal(entity.Event{
+			EventType:   entity.EventTypeConversion,
+			EventFamily: entity.EventFamilyCDP,
+			Payload: map[string]interface{}{
+				"conversion_identifier": identifier,
+				"email":                 "email@email.com",
+				"name":                  "nome",
+				"cf_plano":              "anual",
+			},
+		})
+		require.NoError(t, err)
+
+		return data
+	}
+
+	t.Run("success", func(t *testing.T) {
+		client.EXPECT().Request(fmt.Sprintf("%s%s", RDURL, RDEventsPath), http.MethodPost, conversion("lp-ebook")).Return(nil, nil)
+
+		err := rd.EnrollInWorkflow(&workflow, &lead, "")
+		require.NoError(t, err)
+	})
+
+	t.Run("chosen identifier", func(t *testing.T) {
+		client.EXPECT().Request(fmt.Sprintf("%s%s", RDURL, RDEventsPath), http.MethodPost, conversion("popup-ebook")).Return(nil, nil)
+
+		err := rd.EnrollInWorkflow(&workflow, &lead, "popup-ebook")
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown identifier", func(t *testing.T) {
+		err := rd.EnrollInWorkflow(&workflow, &lead, "batata")
+		require.True(t, errors.Is(err, ErrNoConversionEntry))
+	})
+
+	t.Run("no conversion entry", func(t *testing.T) {
+		workflow := entity.Workflow{Name: "segmentacao", EntryCriteria: []entity.WorkflowEntryCriterion{
+			{Type: entity.WorkflowEntrySegmentation, SegmentationID: 7},
+		}}
+
+		err := rd.EnrollInWorkflow(&workflow, &lead, "")
+		require.True(t, errors.Is(err, ErrNoConversionEntry))
+	})
+}